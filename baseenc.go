@@ -0,0 +1,185 @@
+package num
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	crockfordCheck    = crockfordAlphabet + "*~$=U"
+	base58Alphabet    = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// crockfordWidth is the number of base32 digits needed for 128 bits, which
+	// is the same as the width of a ULID.
+	crockfordWidth = 26
+)
+
+const invalidDigit = 0xff
+
+var (
+	crockfordDecode = func() (out [256]byte) {
+		out = newDecodeMap(crockfordAlphabet)
+		for i := 0; i < len(crockfordAlphabet); i++ {
+			c := crockfordAlphabet[i]
+			if c >= 'A' && c <= 'Z' {
+				out[c+'a'-'A'] = byte(i)
+			}
+		}
+		out['O'], out['o'] = 0, 0
+		out['I'], out['i'], out['L'], out['l'] = 1, 1, 1, 1
+		return out
+	}()
+
+	base58Decode = newDecodeMap(base58Alphabet)
+	base62Decode = newDecodeMap(base62Alphabet)
+)
+
+func newDecodeMap(alphabet string) (out [256]byte) {
+	for i := range out {
+		out[i] = invalidDigit
+	}
+	for i := 0; i < len(alphabet); i++ {
+		out[alphabet[i]] = byte(i)
+	}
+	return out
+}
+
+// Base32Crockford formats u as 26 digits of Crockford's base32, which is the
+// encoding used by ULID, i.e. "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" for MaxU128.
+//
+// If check is true, Crockford's check symbol (the value mod 37) is appended,
+// making the result 27 characters long.
+func (u U128) Base32Crockford(check bool) string {
+	buf := make([]byte, crockfordWidth, crockfordWidth+1)
+	v := u
+	for i := crockfordWidth - 1; i >= 0; i-- {
+		buf[i] = crockfordAlphabet[v.lo&0x1f]
+		v = v.Rsh(5)
+	}
+	if check {
+		buf = append(buf, crockfordCheck[u.Rem64(37).lo])
+	}
+	return string(buf)
+}
+
+// U128FromBase32Crockford parses a Crockford base32 string, as formatted by
+// U128.Base32Crockford. Decoding is case-insensitive, 'O' is read as '0' and
+// 'I' and 'L' are read as '1', and hyphens are ignored. Strings shorter than
+// 26 digits are accepted.
+//
+// If check is true, the last character must be a valid check symbol for the
+// value.
+//
+// If s is not valid, err is a *NumError wrapping ErrSyntax. If the value is
+// larger than MaxU128, err wraps ErrRange.
+func U128FromBase32Crockford(s string, check bool) (out U128, err error) {
+	const fn = "U128FromBase32Crockford"
+
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			digits = append(digits, s[i])
+		}
+	}
+
+	var sym byte
+	if check {
+		if len(digits) == 0 {
+			return out, syntaxError(fn, s)
+		}
+		sym = digits[len(digits)-1]
+		if sym >= 'a' && sym <= 'z' {
+			sym -= 'a' - 'A'
+		}
+		digits = digits[:len(digits)-1]
+	}
+
+	out, inRange, ok := parseBase(digits, 32, &crockfordDecode)
+	if !ok {
+		return zeroU128, syntaxError(fn, s)
+	} else if !inRange {
+		return zeroU128, rangeError(fn, s)
+	}
+	if check && crockfordCheck[out.Rem64(37).lo] != sym {
+		return zeroU128, syntaxError(fn, s)
+	}
+	return out, nil
+}
+
+// Base58 formats u using the Bitcoin base58 alphabet, without leading
+// zeros. Zero is "1". At most 22 characters are required.
+//
+// Base58 encodes the number, not its bytes, so leading zero bytes are not
+// represented as they are in Bitcoin addresses.
+func (u U128) Base58() string {
+	return string(appendBase(nil, u, base58Alphabet))
+}
+
+// U128FromBase58 parses a Bitcoin-alphabet base58 string, as formatted by
+// U128.Base58. The alphabet is case-sensitive. Leading '1' characters (zero
+// digits) are permitted.
+//
+// If s is not valid, err is a *NumError wrapping ErrSyntax. If the value is
+// larger than MaxU128, err wraps ErrRange.
+func U128FromBase58(s string) (out U128, err error) {
+	return u128FromBase("U128FromBase58", s, 58, &base58Decode)
+}
+
+// Base62 formats u using the alphabet 0-9, A-Z, a-z, without leading zeros.
+// At most 22 characters are required.
+func (u U128) Base62() string {
+	return string(appendBase(nil, u, base62Alphabet))
+}
+
+// U128FromBase62 parses a base62 string, as formatted by U128.Base62. The
+// alphabet is case-sensitive.
+//
+// If s is not valid, err is a *NumError wrapping ErrSyntax. If the value is
+// larger than MaxU128, err wraps ErrRange.
+func U128FromBase62(s string) (out U128, err error) {
+	return u128FromBase("U128FromBase62", s, 62, &base62Decode)
+}
+
+func appendBase(dst []byte, u U128, alphabet string) []byte {
+	var buf [128]byte
+	i := len(buf)
+	base := uint64(len(alphabet))
+	for {
+		var r U128
+		u, r = u.QuoRem64(base)
+		i--
+		buf[i] = alphabet[r.lo]
+		if u.IsZero() {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+func u128FromBase(fn string, s string, base uint64, decode *[256]byte) (out U128, err error) {
+	out, inRange, ok := parseBase([]byte(s), base, decode)
+	if !ok {
+		return zeroU128, syntaxError(fn, s)
+	} else if !inRange {
+		return zeroU128, rangeError(fn, s)
+	}
+	return out, nil
+}
+
+// parseBase parses digits using the decode map for an alphabet. It has the
+// same semantics as parseMagnitude.
+func parseBase(digits []byte, base uint64, decode *[256]byte) (out U128, inRange bool, ok bool) {
+	if len(digits) == 0 {
+		return out, false, false
+	}
+	inRange = true
+	for _, c := range digits {
+		d := decode[c]
+		if d == invalidDigit {
+			return zeroU128, false, false
+		}
+		if inRange {
+			if out, inRange = mulAdd64(out, base, uint64(d)); !inRange {
+				out = MaxU128
+			}
+		}
+	}
+	return out, inRange, true
+}
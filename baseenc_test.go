@@ -0,0 +1,152 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestBaseEncodings(t *testing.T) {
+	for idx, tc := range []struct {
+		in        U128
+		crockford string
+		check     string
+		base58    string
+		base62    string
+	}{
+		{zeroU128, "00000000000000000000000000", "0", "1", "0"},
+		{u64(1), "00000000000000000000000001", "1", "2", "1"},
+		{u64(31), "0000000000000000000000000Z", "Z", "Y", "V"},
+		{u64(32), "00000000000000000000000010", "*", "Z", "W"},
+		{u64(1234), "0000000000000000000000016J", "D", "NH", "Ju"},
+		{u64(maxUint64), "0000000000000FZZZZZZZZZZZZ", "B", "jpXCZedGfVQ", "LygHa16AHYF"},
+		{MaxU128, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "*", "YcVfxkQb6JRzqk5kF2tNLv", "7n42DGM5Tflk9n8mt7Fhc7"},
+		{u128s("0x0123456789abcdef0123456789abcdef"), "014D2PF2DBSQQG28T5CY4TQKFF", "K", "99dn6s7bZoVpjzYciVNgN", "296tiiBb3U904RIpygpjj"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.crockford, tc.in.Base32Crockford(false))
+			tt.MustEqual(tc.crockford+tc.check, tc.in.Base32Crockford(true))
+			tt.MustEqual(tc.base58, tc.in.Base58())
+			tt.MustEqual(tc.base62, tc.in.Base62())
+
+			for _, s := range []string{tc.crockford, strings.ToLower(tc.crockford)} {
+				v, err := U128FromBase32Crockford(s, false)
+				tt.MustOK(err)
+				tt.MustEqual(tc.in, v)
+			}
+			v, err := U128FromBase32Crockford(strings.ToLower(tc.crockford+tc.check), true)
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+
+			v, err = U128FromBase58(tc.base58)
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+
+			v, err = U128FromBase62(tc.base62)
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestU128FromBase32Crockford(t *testing.T) {
+	for idx, tc := range []struct {
+		in    string
+		check bool
+		out   U128
+		err   error
+	}{
+		{"0", false, zeroU128, nil},
+		{"16J", false, u64(1234), nil},
+		{"16j", false, u64(1234), nil},
+		{"16JD", true, u64(1234), nil},
+		{"16jd", true, u64(1234), nil},
+		{"1-6-J", false, u64(1234), nil},
+		{"oOiIlL", false, u64(0x8421), nil},
+		{"10*", true, u64(32), nil},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", false, MaxU128, nil},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ*", true, MaxU128, nil},
+		{"0007ZZZZZZZZZZZZZZZZZZZZZZZZZ", false, MaxU128, nil},
+		{"80000000000000000000000000", false, zeroU128, ErrRange},
+		{"ZZZZZZZZZZZZZZZZZZZZZZZZZZZ", false, zeroU128, ErrRange},
+		{"", false, zeroU128, ErrSyntax},
+		{"-", false, zeroU128, ErrSyntax},
+		{"", true, zeroU128, ErrSyntax},
+		{"0", true, zeroU128, ErrSyntax},
+		{"16JE", true, zeroU128, ErrSyntax},
+		{"16J", true, zeroU128, ErrSyntax},
+		{"U", false, zeroU128, ErrSyntax},
+		{"1U", true, zeroU128, ErrSyntax},
+		{"0U", true, zeroU128, ErrSyntax},
+		{"*", false, zeroU128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			v, err := U128FromBase32Crockford(tc.in, tc.check)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, v)
+		})
+	}
+}
+
+func TestU128FromBase58And62Errors(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		b58 error
+		b62 error
+	}{
+		{"", ErrSyntax, ErrSyntax},
+		{"0", ErrSyntax, nil},
+		{"O", ErrSyntax, nil},
+		{"I", ErrSyntax, nil},
+		{"l", ErrSyntax, nil},
+		{"-1", ErrSyntax, ErrSyntax},
+		{"YcVfxkQb6JRzqk5kF2tNLw", ErrRange, ErrRange},
+		{"7n42DGM5Tflk9n8mt7Fhc8", ErrSyntax, ErrRange},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz", ErrRange, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			_, err := U128FromBase58(tc.in)
+			if tc.b58 != nil {
+				tt.MustAssert(errors.Is(err, tc.b58), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			_, err = U128FromBase62(tc.in)
+			if tc.b62 != nil {
+				tt.MustAssert(errors.Is(err, tc.b62), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+		})
+	}
+}
+
+func TestBaseEncodingsRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randU128(scratch)
+
+		v, err := U128FromBase32Crockford(u.Base32Crockford(true), true)
+		tt.MustOK(err)
+		tt.MustEqual(u, v)
+
+		v, err = U128FromBase58(u.Base58())
+		tt.MustOK(err)
+		tt.MustEqual(u, v)
+
+		v, err = U128FromBase62(u.Base62())
+		tt.MustOK(err)
+		tt.MustEqual(u, v)
+	}
+}
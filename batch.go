@@ -0,0 +1,130 @@
+package num
+
+import (
+	"io"
+)
+
+// batchSize is the number of values encoded or decoded per call to
+// Write or Read by the bulk I/O functions.
+const batchSize = 256
+
+// AppendU128s appends the 16 byte encoding of each value in vals to dst using
+// the given byte order and returns the extended buffer.
+func AppendU128s(dst []byte, order ByteOrder, vals []U128) []byte {
+	for _, v := range vals {
+		dst = order.AppendU128(dst, v)
+	}
+	return dst
+}
+
+// AppendI128s appends the 16 byte two's complement encoding of each value in
+// vals to dst using the given byte order and returns the extended buffer.
+func AppendI128s(dst []byte, order ByteOrder, vals []I128) []byte {
+	for _, v := range vals {
+		dst = order.AppendI128(dst, v)
+	}
+	return dst
+}
+
+// WriteU128s writes the 16 byte encoding of each value in vals to w using the
+// given byte order. Values are encoded in batches, so w sees a small number
+// of large writes rather than one write per value.
+//
+// ClickHouse's RowBinary and Native formats encode UInt128 as LittleEndian.
+func WriteU128s(w io.Writer, order ByteOrder, vals []U128) error {
+	var buf [batchSize * 16]byte
+	for len(vals) > 0 {
+		n := len(vals)
+		if n > batchSize {
+			n = batchSize
+		}
+		for i, v := range vals[:n] {
+			order.PutU128(buf[i*16:], v)
+		}
+		if _, err := w.Write(buf[:n*16]); err != nil {
+			return err
+		}
+		vals = vals[n:]
+	}
+	return nil
+}
+
+// WriteI128s writes the 16 byte two's complement encoding of each value in
+// vals to w using the given byte order. Values are encoded in batches, as
+// they are by WriteU128s.
+//
+// ClickHouse's RowBinary and Native formats encode Int128 as LittleEndian.
+// Decimal128(S) uses the same encoding for the value scaled by 10^S.
+func WriteI128s(w io.Writer, order ByteOrder, vals []I128) error {
+	var buf [batchSize * 16]byte
+	for len(vals) > 0 {
+		n := len(vals)
+		if n > batchSize {
+			n = batchSize
+		}
+		for i, v := range vals[:n] {
+			order.PutI128(buf[i*16:], v)
+		}
+		if _, err := w.Write(buf[:n*16]); err != nil {
+			return err
+		}
+		vals = vals[n:]
+	}
+	return nil
+}
+
+// ReadU128s reads len(dst) values encoded with the given byte order from r
+// into dst, and returns the number of values read.
+//
+// As with io.ReadFull, err is io.EOF only if nothing was read. If r ends
+// after some but not all of the values were read, err is io.ErrUnexpectedEOF
+// and n is the number of complete values read.
+func ReadU128s(r io.Reader, order ByteOrder, dst []U128) (n int, err error) {
+	var buf [batchSize * 16]byte
+	for n < len(dst) {
+		m := len(dst) - n
+		if m > batchSize {
+			m = batchSize
+		}
+		read, err := io.ReadFull(r, buf[:m*16])
+		for i := 0; i < read/16; i++ {
+			dst[n] = order.U128(buf[i*16:])
+			n++
+		}
+		if err != nil {
+			return n, readBatchError(err, n)
+		}
+	}
+	return n, nil
+}
+
+// ReadI128s reads len(dst) two's complement values encoded with the given
+// byte order from r into dst, and returns the number of values read. Errors
+// are reported in the same way as ReadU128s.
+func ReadI128s(r io.Reader, order ByteOrder, dst []I128) (n int, err error) {
+	var buf [batchSize * 16]byte
+	for n < len(dst) {
+		m := len(dst) - n
+		if m > batchSize {
+			m = batchSize
+		}
+		read, err := io.ReadFull(r, buf[:m*16])
+		for i := 0; i < read/16; i++ {
+			dst[n] = order.I128(buf[i*16:])
+			n++
+		}
+		if err != nil {
+			return n, readBatchError(err, n)
+		}
+	}
+	return n, nil
+}
+
+// readBatchError converts io.EOF to io.ErrUnexpectedEOF if any values were
+// read in an earlier batch, as io.ReadFull does.
+func readBatchError(err error, n int) error {
+	if err == io.EOF && n > 0 {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
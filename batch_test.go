@@ -0,0 +1,125 @@
+package num
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(b)
+}
+
+func TestWriteReadU128s(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+
+	for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+		for _, n := range []int{0, 1, batchSize - 1, batchSize, batchSize + 1, batchSize*3 + 7} {
+			vals := make([]U128, n)
+			for i := range vals {
+				vals[i] = randU128(scratch)
+			}
+
+			var w countingWriter
+			tt.MustOK(WriteU128s(&w, order, vals))
+			tt.MustEqual((n+batchSize-1)/batchSize, w.writes)
+			tt.MustEqual(AppendU128s(nil, order, vals), w.Bytes())
+
+			out := make([]U128, n)
+			rn, err := ReadU128s(iotest.HalfReader(&w), order, out)
+			tt.MustOK(err)
+			tt.MustEqual(n, rn)
+			tt.MustEqual(vals, out)
+		}
+	}
+}
+
+func TestWriteReadI128s(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+
+	for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+		for _, n := range []int{0, 1, batchSize, batchSize*2 + 1} {
+			vals := make([]I128, n)
+			for i := range vals {
+				vals[i] = randI128(scratch)
+				if i%2 == 0 {
+					vals[i] = vals[i].Neg()
+				}
+			}
+
+			var buf bytes.Buffer
+			tt.MustOK(WriteI128s(&buf, order, vals))
+			tt.MustEqual(AppendI128s(nil, order, vals), buf.Bytes())
+
+			out := make([]I128, n)
+			rn, err := ReadI128s(&buf, order, out)
+			tt.MustOK(err)
+			tt.MustEqual(n, rn)
+			tt.MustEqual(vals, out)
+		}
+	}
+}
+
+func TestClickHouseRowBinary(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	// SELECT toUInt128(1), toInt128(-2) FORMAT RowBinary:
+	var buf bytes.Buffer
+	tt.MustOK(WriteU128s(&buf, LittleEndian, []U128{u64(1)}))
+	tt.MustOK(WriteI128s(&buf, LittleEndian, []I128{i64(-2)}))
+	tt.MustEqual([]byte{
+		0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}, buf.Bytes())
+}
+
+func TestReadU128sShort(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	out := make([]U128, 3)
+	n, err := ReadU128s(bytes.NewReader(nil), LittleEndian, out)
+	tt.MustEqual(0, n)
+	tt.MustEqual(io.EOF, err)
+
+	n, err = ReadU128s(bytes.NewReader(make([]byte, 24)), LittleEndian, out)
+	tt.MustEqual(1, n)
+	tt.MustEqual(io.ErrUnexpectedEOF, err)
+
+	n, err = ReadU128s(bytes.NewReader(make([]byte, 32)), LittleEndian, out)
+	tt.MustEqual(2, n)
+	tt.MustEqual(io.ErrUnexpectedEOF, err)
+
+	big := make([]U128, batchSize+1)
+	n, err = ReadU128s(bytes.NewReader(make([]byte, batchSize*16)), LittleEndian, big)
+	tt.MustEqual(batchSize, n)
+	tt.MustEqual(io.ErrUnexpectedEOF, err)
+
+	n, err = ReadU128s(bytes.NewReader(nil), LittleEndian, nil)
+	tt.MustEqual(0, n)
+	tt.MustOK(err)
+}
+
+func TestWriteU128sError(t *testing.T) {
+	tt := assert.WrapTB(t)
+	err := WriteU128s(errWriter{}, LittleEndian, []U128{u64(1)})
+	tt.MustAssert(errors.Is(err, io.ErrClosedPipe))
+
+	err = WriteU128s(errWriter{}, LittleEndian, nil)
+	tt.MustOK(err)
+}
+
+type errWriter struct{}
+
+func (errWriter) Write(b []byte) (int, error) { return 0, io.ErrClosedPipe }
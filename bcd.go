@@ -0,0 +1,153 @@
+package num
+
+import (
+	"encoding/hex"
+)
+
+// Sign nibbles used by packed decimal (COBOL COMP-3). When decoding, A, C, E
+// and F are all positive and B and D are negative, but only C and D are
+// written.
+const (
+	bcdPositive = 0xc
+	bcdNegative = 0xd
+)
+
+// AppendPackedBCD appends u to dst as a size byte packed binary-coded
+// decimal, with two digits per byte, and returns the extended buffer. The
+// number is right-aligned and padded with leading zeros.
+//
+// If signed is true, the last nibble holds the sign, as in COBOL COMP-3 or
+// IBM packed decimal, leaving room for 2*size-1 digits. U128 always uses the
+// positive sign nibble, 0xC.
+//
+// If u does not fit in size bytes, err is a *NumError wrapping ErrRange.
+func (u U128) AppendPackedBCD(dst []byte, size int, signed bool) ([]byte, error) {
+	return appendPackedBCD("U128.AppendPackedBCD", dst, size, signed, false, u)
+}
+
+// AppendPackedBCD appends i to dst as a size byte packed binary-coded
+// decimal and returns the extended buffer. See U128.AppendPackedBCD for
+// details.
+//
+// If signed is false, i must not be negative. If i does not fit in size
+// bytes, err is a *NumError wrapping ErrRange.
+func (i I128) AppendPackedBCD(dst []byte, size int, signed bool) ([]byte, error) {
+	neg := i.Sign() < 0
+	if neg && !signed {
+		return dst, rangeError("I128.AppendPackedBCD", i.String())
+	}
+	return appendPackedBCD("I128.AppendPackedBCD", dst, size, signed, neg, i.AbsU128())
+}
+
+func appendPackedBCD(fn string, dst []byte, size int, signed bool, neg bool, mag U128) ([]byte, error) {
+	digits := mag.String()
+	nibbles := 2 * size
+	if signed {
+		nibbles--
+	}
+	if size <= 0 || len(digits) > nibbles {
+		str := digits
+		if neg {
+			str = "-" + str
+		}
+		return dst, rangeError(fn, str)
+	}
+
+	start := len(dst)
+	for n := 0; n < size; n++ {
+		dst = append(dst, 0)
+	}
+	out := dst[start:]
+
+	// Nibbles are filled from the right:
+	pos := 2*size - 1
+	if signed {
+		sign := byte(bcdPositive)
+		if neg {
+			sign = bcdNegative
+		}
+		out[pos/2] |= sign
+		pos--
+	}
+	for j := len(digits) - 1; j >= 0; j-- {
+		d := digits[j] - '0'
+		if pos%2 == 0 {
+			d <<= 4
+		}
+		out[pos/2] |= d
+		pos--
+	}
+	return dst, nil
+}
+
+// U128FromPackedBCD decodes a packed binary-coded decimal, as written by
+// U128.AppendPackedBCD. b may be any length; leading zeros are ignored.
+//
+// If signed is true, the last nibble is a sign; a negative sign is only
+// accepted if the value is zero.
+//
+// If b contains a nibble that is not a decimal digit, or an invalid sign,
+// err is a *NumError wrapping ErrSyntax. If the value does not fit in a U128,
+// err wraps ErrRange.
+func U128FromPackedBCD(b []byte, signed bool) (out U128, err error) {
+	neg, mag, err := parsePackedBCD("U128FromPackedBCD", b, signed)
+	if err != nil {
+		return out, err
+	}
+	out, inRange := u128FromLiteral(neg, mag, true)
+	if !inRange {
+		return zeroU128, rangeError("U128FromPackedBCD", hex.EncodeToString(b))
+	}
+	return out, nil
+}
+
+// I128FromPackedBCD decodes a packed binary-coded decimal, as written by
+// I128.AppendPackedBCD. Errors are reported in the same way as
+// U128FromPackedBCD.
+func I128FromPackedBCD(b []byte, signed bool) (out I128, err error) {
+	neg, mag, err := parsePackedBCD("I128FromPackedBCD", b, signed)
+	if err != nil {
+		return out, err
+	}
+	out, inRange := i128FromLiteral(neg, mag, true)
+	if !inRange {
+		return zeroI128, rangeError("I128FromPackedBCD", hex.EncodeToString(b))
+	}
+	return out, nil
+}
+
+func parsePackedBCD(fn string, b []byte, signed bool) (neg bool, mag U128, err error) {
+	nibbles := 2 * len(b)
+	if signed {
+		if len(b) == 0 {
+			return false, mag, syntaxError(fn, "")
+		}
+		nibbles--
+		switch b[len(b)-1] & 0xf {
+		case 0xa, 0xc, 0xe, 0xf:
+		case 0xb, 0xd:
+			neg = true
+		default:
+			return false, mag, syntaxError(fn, hex.EncodeToString(b))
+		}
+	}
+
+	inRange := true
+	for n := 0; n < nibbles; n++ {
+		d := b[n/2]
+		if n%2 == 0 {
+			d >>= 4
+		}
+		d &= 0xf
+		if d > 9 {
+			return false, mag, syntaxError(fn, hex.EncodeToString(b))
+		}
+		if inRange {
+			mag, inRange = mulAdd64(mag, 10, uint64(d))
+		}
+	}
+	if !inRange {
+		return false, mag, rangeError(fn, hex.EncodeToString(b))
+	}
+	return neg, mag, nil
+}
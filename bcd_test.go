@@ -0,0 +1,158 @@
+package num
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128PackedBCD(t *testing.T) {
+	for idx, tc := range []struct {
+		in     U128
+		size   int
+		signed bool
+		out    string
+	}{
+		{zeroU128, 1, false, "00"},
+		{zeroU128, 1, true, "0c"},
+		{u64(9), 1, true, "9c"},
+		{u64(12), 1, false, "12"},
+		{u64(123), 2, false, "0123"},
+		{u64(123), 2, true, "123c"},
+		{u64(1234), 3, true, "01234c"},
+		{MaxU128, 20, false, "0340282366920938463463374607431768211455"},
+		{MaxU128, 20, true, "340282366920938463463374607431768211455c"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%d/%v", idx, tc.in, tc.size, tc.signed), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			out, err := tc.in.AppendPackedBCD([]byte{0xaa}, tc.size, tc.signed)
+			tt.MustOK(err)
+			tt.MustEqual("aa"+tc.out, hex.EncodeToString(out))
+
+			v, err := U128FromPackedBCD(out[1:], tc.signed)
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestI128PackedBCD(t *testing.T) {
+	for idx, tc := range []struct {
+		in     I128
+		size   int
+		signed bool
+		out    string
+	}{
+		{zeroI128, 1, true, "0c"},
+		{i64(-1), 1, true, "1d"},
+		{i64(-12345), 3, true, "12345d"},
+		{i64(12345), 4, true, "0012345c"},
+		{i64(12345), 3, false, "012345"},
+
+		// PIC S9(31) COMP-3:
+		{MustI128FromString("-1234567890123456789012345678901"), 16, true, "1234567890123456789012345678901d"},
+		{MinI128, 20, true, "170141183460469231731687303715884105728d"},
+		{MaxI128, 20, true, "170141183460469231731687303715884105727c"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%d/%v", idx, tc.in, tc.size, tc.signed), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			out, err := tc.in.AppendPackedBCD(nil, tc.size, tc.signed)
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, hex.EncodeToString(out))
+
+			v, err := I128FromPackedBCD(out, tc.signed)
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestPackedBCDEncodeRange(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	for _, tc := range []struct {
+		size   int
+		signed bool
+	}{{0, false}, {0, true}, {-1, false}, {1, true}, {2, false}, {2, true}} {
+		out, err := u64(12345).AppendPackedBCD([]byte{0xaa}, tc.size, tc.signed)
+		tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+		tt.MustEqual([]byte{0xaa}, out)
+	}
+
+	_, err := MaxU128.AppendPackedBCD(nil, 19, false)
+	tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+	_, err = MaxU128.AppendPackedBCD(nil, 20, false)
+	tt.MustOK(err)
+
+	_, err = i64(-1).AppendPackedBCD(nil, 1, false)
+	tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+
+	_, err = i64(-100).AppendPackedBCD(nil, 1, true)
+	tt.MustEqual(`num: I128.AppendPackedBCD: parsing "-100": value out of range`, err.Error())
+}
+
+func TestPackedBCDDecode(t *testing.T) {
+	for idx, tc := range []struct {
+		in     string
+		signed bool
+		u      U128
+		uErr   error
+		i      I128
+		iErr   error
+	}{
+		{"", false, zeroU128, nil, zeroI128, nil},
+		{"", true, zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"123f", true, u64(123), nil, i64(123), nil},
+		{"123a", true, u64(123), nil, i64(123), nil},
+		{"123e", true, u64(123), nil, i64(123), nil},
+		{"123b", true, zeroU128, ErrRange, i64(-123), nil},
+		{"000d", true, zeroU128, nil, zeroI128, nil},
+		{"1239", true, zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"123c", false, zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"1a3c", true, zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"0000000340282366920938463463374607431768211455", false, MaxU128, nil, zeroI128, ErrRange},
+		{"0340282366920938463463374607431768211456", false, zeroU128, ErrRange, zeroI128, ErrRange},
+		{"9999999999999999999999999999999999999999999999", false, zeroU128, ErrRange, zeroI128, ErrRange},
+		{"170141183460469231731687303715884105729d", true, zeroU128, ErrRange, zeroI128, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			b, _ := hex.DecodeString(tc.in)
+
+			u, err := U128FromPackedBCD(b, tc.signed)
+			if tc.uErr != nil {
+				tt.MustAssert(errors.Is(err, tc.uErr), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.u, u)
+
+			i, err := I128FromPackedBCD(b, tc.signed)
+			if tc.iErr != nil {
+				tt.MustAssert(errors.Is(err, tc.iErr), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.i, i)
+		})
+	}
+}
+
+func TestPackedBCDRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randI128(scratch)
+		if i%2 == 0 {
+			v = v.Neg()
+		}
+		b, err := v.AppendPackedBCD(nil, 20, true)
+		tt.MustOK(err)
+		r, err := I128FromPackedBCD(b, true)
+		tt.MustOK(err)
+		tt.MustEqual(v, r)
+	}
+}
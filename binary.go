@@ -0,0 +1,77 @@
+package num
+
+import (
+	"encoding/hex"
+)
+
+// binaryVersion is the first byte of the encoding produced by MarshalBinary.
+// It allows the format to change in future without breaking existing data.
+const binaryVersion = 1
+
+const binarySize = 1 + 16
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a version
+// byte followed by the 16 byte big-endian value.
+func (u U128) MarshalBinary() ([]byte, error) {
+	out := make([]byte, binarySize)
+	out[0] = binaryVersion
+	u.PutBigEndian(out[1:])
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the format
+// written by MarshalBinary.
+func (u *U128) UnmarshalBinary(data []byte) error {
+	return u.unmarshalBinary("U128.UnmarshalBinary", data)
+}
+
+// GobEncode implements gob.GobEncoder using the same format as MarshalBinary.
+func (u U128) GobEncode() ([]byte, error) {
+	return u.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the same format as
+// UnmarshalBinary.
+func (u *U128) GobDecode(data []byte) error {
+	return u.unmarshalBinary("U128.GobDecode", data)
+}
+
+func (u *U128) unmarshalBinary(fn string, data []byte) error {
+	if len(data) != binarySize || data[0] != binaryVersion {
+		return syntaxError(fn, hex.EncodeToString(data))
+	}
+	*u = MustU128FromBigEndian(data[1:])
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a version
+// byte followed by the 16 byte big-endian two's complement value.
+func (i I128) MarshalBinary() ([]byte, error) {
+	return U128{hi: i.hi, lo: i.lo}.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding the format
+// written by MarshalBinary.
+func (i *I128) UnmarshalBinary(data []byte) error {
+	return i.unmarshalBinary("I128.UnmarshalBinary", data)
+}
+
+// GobEncode implements gob.GobEncoder using the same format as MarshalBinary.
+func (i I128) GobEncode() ([]byte, error) {
+	return i.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the same format as
+// UnmarshalBinary.
+func (i *I128) GobDecode(data []byte) error {
+	return i.unmarshalBinary("I128.GobDecode", data)
+}
+
+func (i *I128) unmarshalBinary(fn string, data []byte) error {
+	var u U128
+	if err := u.unmarshalBinary(fn, data); err != nil {
+		return err
+	}
+	*i = I128{hi: u.hi, lo: u.lo}
+	return nil
+}
@@ -0,0 +1,152 @@
+package num
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = U128{}
+	_ encoding.BinaryUnmarshaler = &U128{}
+	_ gob.GobEncoder             = U128{}
+	_ gob.GobDecoder             = &U128{}
+	_ encoding.BinaryMarshaler   = I128{}
+	_ encoding.BinaryUnmarshaler = &I128{}
+	_ gob.GobEncoder             = I128{}
+	_ gob.GobDecoder             = &I128{}
+)
+
+func TestU128MarshalBinary(t *testing.T) {
+	for idx, tc := range []struct {
+		in  U128
+		out string
+	}{
+		{zeroU128, "0100000000000000000000000000000000"},
+		{u64(1), "0100000000000000000000000000000001"},
+		{U128FromRaw(0x0102030405060708, 0x090a0b0c0d0e0f10), "010102030405060708090a0b0c0d0e0f10"},
+		{MaxU128, "01ffffffffffffffffffffffffffffffff"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, err := tc.in.MarshalBinary()
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, hex.EncodeToString(bts))
+
+			var result U128
+			tt.MustOK(result.UnmarshalBinary(bts))
+			tt.MustEqual(tc.in, result)
+		})
+	}
+}
+
+func TestI128MarshalBinary(t *testing.T) {
+	for idx, tc := range []struct {
+		in  I128
+		out string
+	}{
+		{zeroI128, "0100000000000000000000000000000000"},
+		{i64(1), "0100000000000000000000000000000001"},
+		{i64(-1), "01ffffffffffffffffffffffffffffffff"},
+		{i64(-2), "01fffffffffffffffffffffffffffffffe"},
+		{MaxI128, "017fffffffffffffffffffffffffffffff"},
+		{MinI128, "0180000000000000000000000000000000"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, err := tc.in.MarshalBinary()
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, hex.EncodeToString(bts))
+
+			var result I128
+			tt.MustOK(result.UnmarshalBinary(bts))
+			tt.MustEqual(tc.in, result)
+		})
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	for idx, in := range []string{
+		"",
+		"01",
+		"00000000000000000000000000000000",
+		"010000000000000000000000000000000000",
+		"0200000000000000000000000000000000",
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, _ := hex.DecodeString(in)
+
+			u := u64(1234)
+			err := u.UnmarshalBinary(bts)
+			tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+			tt.MustEqual(u64(1234), u)
+
+			i := i64(-1234)
+			err = i.GobDecode(bts)
+			tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+			tt.MustEqual(i64(-1234), i)
+
+			var nerr *NumError
+			tt.MustAssert(errors.As(err, &nerr))
+			tt.MustEqual("I128.GobDecode", nerr.Func)
+			tt.MustEqual(in, nerr.Num)
+		})
+	}
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	type record struct {
+		U    U128
+		I    I128
+		Us   []U128
+		Is   map[string]I128
+		UPtr *U128
+	}
+
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+
+	var in []record
+	for i := 0; i < 100; i++ {
+		u := randU128(scratch)
+		rec := record{
+			U:    u,
+			I:    randI128(scratch).Neg(),
+			Us:   []U128{zeroU128, MaxU128, randU128(scratch)},
+			Is:   map[string]I128{"min": MinI128, "max": MaxI128, "neg": i64(-1)},
+			UPtr: &u,
+		}
+		in = append(in, rec)
+	}
+
+	var buf bytes.Buffer
+	tt.MustOK(gob.NewEncoder(&buf).Encode(in))
+
+	var out []record
+	tt.MustOK(gob.NewDecoder(&buf).Decode(&out))
+	tt.MustEqual(in, out)
+}
+
+func TestGobRoundTripScalar(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	tt.MustOK(enc.Encode(MaxU128))
+	tt.MustOK(enc.Encode(MinI128))
+
+	var u U128
+	var i I128
+	dec := gob.NewDecoder(&buf)
+	tt.MustOK(dec.Decode(&u))
+	tt.MustOK(dec.Decode(&i))
+	tt.MustEqual(MaxU128, u)
+	tt.MustEqual(MinI128, i)
+}
@@ -0,0 +1,138 @@
+package num
+
+import (
+	"encoding/hex"
+)
+
+// Bytes returns the minimal big-endian representation of u, with no leading
+// zero bytes, in the same way as big.Int.Bytes. Zero is an empty slice.
+//
+// See U128FromBytes for the counterpart.
+func (u U128) Bytes() []byte {
+	return u.AppendBytes(make([]byte, 0, u.byteLen()))
+}
+
+// AppendBytes appends the minimal big-endian representation of u, as
+// returned by Bytes, to dst and returns the extended buffer.
+func (u U128) AppendBytes(dst []byte) []byte {
+	var buf [16]byte
+	u.PutBigEndian(buf[:])
+	return append(dst, buf[16-u.byteLen():]...)
+}
+
+// FillBytes sets buf to the big-endian representation of u, right-aligned
+// and zero padded, and returns buf. It panics if u does not fit in buf, as
+// big.Int.FillBytes does.
+func (u U128) FillBytes(buf []byte) []byte {
+	n := u.byteLen()
+	if n > len(buf) {
+		panic("num: U128.FillBytes: buffer too small to fit value")
+	}
+	var tmp [16]byte
+	u.PutBigEndian(tmp[:])
+	fill(buf, 0)
+	copy(buf[len(buf)-n:], tmp[16-n:])
+	return buf
+}
+
+func (u U128) byteLen() int {
+	return (u.BitLen() + 7) / 8
+}
+
+// U128FromBytes interprets b as a big-endian unsigned integer, in the same way
+// as big.Int.SetBytes. b may be between 0 and 16 bytes long; an empty slice is
+// zero. Longer slices are accepted if the extra leading bytes are all zero,
+// otherwise err is a *NumError wrapping ErrRange.
+func U128FromBytes(b []byte) (out U128, err error) {
+	v := b
+	for len(v) > 16 && v[0] == 0 {
+		v = v[1:]
+	}
+	if len(v) > 16 {
+		return out, rangeError("U128FromBytes", hex.EncodeToString(b))
+	}
+	var buf [16]byte
+	copy(buf[16-len(v):], v)
+	return MustU128FromBigEndian(buf[:]), nil
+}
+
+// Bytes returns the minimal big-endian two's complement representation of i,
+// which always contains at least one byte and whose most significant bit is
+// the sign bit. This is the same encoding as Java's BigInteger.toByteArray,
+// and is what ASN.1 uses for INTEGER contents.
+//
+// See I128FromBytes for the counterpart.
+func (i I128) Bytes() []byte {
+	return i.AppendBytes(make([]byte, 0, i.byteLen()))
+}
+
+// AppendBytes appends the minimal two's complement representation of i, as
+// returned by Bytes, to dst and returns the extended buffer.
+func (i I128) AppendBytes(dst []byte) []byte {
+	var buf [16]byte
+	i.PutBigEndian(buf[:])
+	return append(dst, buf[16-i.byteLen():]...)
+}
+
+// FillBytes sets buf to the big-endian two's complement representation of i,
+// right-aligned and sign extended, and returns buf. It panics if i does not
+// fit in buf.
+func (i I128) FillBytes(buf []byte) []byte {
+	n := i.byteLen()
+	if n > len(buf) {
+		panic("num: I128.FillBytes: buffer too small to fit value")
+	}
+	var tmp [16]byte
+	i.PutBigEndian(tmp[:])
+	var ext byte
+	if i.hi&signBit != 0 {
+		ext = 0xff
+	}
+	fill(buf, ext)
+	copy(buf[len(buf)-n:], tmp[16-n:])
+	return buf
+}
+
+func (i I128) byteLen() int {
+	// One sign bit is required in addition to the significant bits of the
+	// magnitude, so a full extra byte is added rather than rounding up:
+	u := U128{hi: i.hi, lo: i.lo}
+	if i.hi&signBit != 0 {
+		u = U128{hi: ^i.hi, lo: ^i.lo}
+	}
+	return u.BitLen()/8 + 1
+}
+
+// I128FromBytes interprets b as a big-endian two's complement integer, as
+// returned by Bytes, sign extending it to 128 bits. An empty slice is zero.
+// Slices longer than 16 bytes are accepted if the extra leading bytes are
+// redundant sign extension, otherwise err is a *NumError wrapping ErrRange.
+func I128FromBytes(b []byte) (out I128, err error) {
+	if len(b) == 0 {
+		return out, nil
+	}
+
+	var ext byte
+	if b[0]&0x80 != 0 {
+		ext = 0xff
+	}
+
+	v := b
+	for len(v) > 16 && v[0] == ext && v[1]&0x80 == ext&0x80 {
+		v = v[1:]
+	}
+	if len(v) > 16 {
+		return out, rangeError("I128FromBytes", hex.EncodeToString(b))
+	}
+
+	var buf [16]byte
+	fill(buf[:16-len(v)], ext)
+	copy(buf[16-len(v):], v)
+	return MustI128FromBigEndian(buf[:]), nil
+}
+
+func fill(b []byte, v byte) {
+	for i := range b {
+		b[i] = v
+	}
+}
@@ -0,0 +1,185 @@
+package num
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128Bytes(t *testing.T) {
+	for idx, tc := range []struct {
+		in  U128
+		out string
+	}{
+		{zeroU128, ""},
+		{u64(1), "01"},
+		{u64(0xff), "ff"},
+		{u64(0x100), "0100"},
+		{u64(maxUint64), "ffffffffffffffff"},
+		{U128FromRaw(1, 0), "010000000000000000"},
+		{MaxU128, "ffffffffffffffffffffffffffffffff"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, hex.EncodeToString(tc.in.Bytes()))
+			tt.MustEqual("aa"+tc.out, hex.EncodeToString(tc.in.AppendBytes([]byte{0xaa})))
+			tt.MustEqual(hex.EncodeToString(tc.in.AsBigInt().Bytes()), hex.EncodeToString(tc.in.Bytes()))
+
+			v, err := U128FromBytes(tc.in.Bytes())
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestU128FillBytes(t *testing.T) {
+	tt := assert.WrapTB(t)
+	buf := []byte{1, 2, 3, 4}
+	tt.MustEqual("000000ff", hex.EncodeToString(u64(0xff).FillBytes(buf)))
+	tt.MustEqual("000000ff", hex.EncodeToString(buf))
+
+	buf = make([]byte, 20)
+	tt.MustEqual("00000000ffffffffffffffffffffffffffffffff", hex.EncodeToString(MaxU128.FillBytes(buf)))
+
+	tt.MustEqual("", hex.EncodeToString(zeroU128.FillBytes(nil)))
+
+	defer func() {
+		tt.MustAssert(recover() != nil)
+	}()
+	u64(0x100).FillBytes(make([]byte, 1))
+}
+
+func TestU128FromBytes(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out U128
+		err error
+	}{
+		{"", zeroU128, nil},
+		{"00", zeroU128, nil},
+		{"0001", u64(1), nil},
+		{"ffffffffffffffffffffffffffffffff", MaxU128, nil},
+		{"0000ffffffffffffffffffffffffffffffff", MaxU128, nil},
+		{"010000000000000000000000000000000000", zeroU128, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, _ := hex.DecodeString(tc.in)
+			v, err := U128FromBytes(bts)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, v)
+		})
+	}
+}
+
+func TestI128Bytes(t *testing.T) {
+	for idx, tc := range []struct {
+		in  I128
+		out string
+	}{
+		{zeroI128, "00"},
+		{i64(1), "01"},
+		{i64(127), "7f"},
+		{i64(128), "0080"},
+		{i64(255), "00ff"},
+		{i64(256), "0100"},
+		{i64(-1), "ff"},
+		{i64(-128), "80"},
+		{i64(-129), "ff7f"},
+		{i64(-256), "ff00"},
+		{MaxI128, "7fffffffffffffffffffffffffffffff"},
+		{MinI128, "80000000000000000000000000000000"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, hex.EncodeToString(tc.in.Bytes()))
+			tt.MustEqual("aa"+tc.out, hex.EncodeToString(tc.in.AppendBytes([]byte{0xaa})))
+
+			v, err := I128FromBytes(tc.in.Bytes())
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestI128FillBytes(t *testing.T) {
+	tt := assert.WrapTB(t)
+	tt.MustEqual("ffffff80", hex.EncodeToString(i64(-128).FillBytes(make([]byte, 4))))
+	tt.MustEqual("0000007f", hex.EncodeToString(i64(127).FillBytes([]byte{1, 2, 3, 4})))
+	tt.MustEqual("ffff80000000000000000000000000000000", hex.EncodeToString(MinI128.FillBytes(make([]byte, 18))))
+
+	defer func() {
+		tt.MustAssert(recover() != nil)
+	}()
+	i64(128).FillBytes(make([]byte, 1))
+}
+
+func TestI128FromBytes(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out I128
+		err error
+	}{
+		{"", zeroI128, nil},
+		{"00", zeroI128, nil},
+		{"ff", i64(-1), nil},
+		{"80", i64(-128), nil},
+		{"0080", i64(128), nil},
+		{"ffffff80", i64(-128), nil},
+		{"ffff80000000000000000000000000000000", MinI128, nil},
+		{"00007fffffffffffffffffffffffffffffff", MaxI128, nil},
+		{"ff7fffffffffffffffffffffffffffffffff", zeroI128, ErrRange},
+		{"0080000000000000000000000000000000", zeroI128, ErrRange},
+		{"010000000000000000000000000000000000", zeroI128, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, _ := hex.DecodeString(tc.in)
+			v, err := I128FromBytes(bts)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, v)
+		})
+	}
+}
+
+func TestI128BytesMatchesReference(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randI128(scratch)
+		if i%2 == 0 {
+			v = v.Neg()
+		}
+		tt.MustEqual(javaBytes(v.AsBigInt()), v.Bytes())
+	}
+}
+
+// javaBytes is a reference implementation of Java's BigInteger.toByteArray.
+func javaBytes(v *big.Int) []byte {
+	if v.Sign() >= 0 {
+		b := v.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+	for n := 1; ; n++ {
+		b := new(big.Int).Lsh(big1, uint(n*8))
+		b.Add(b, v)
+		if b.Sign() > 0 && b.BitLen() == n*8 {
+			return b.FillBytes(make([]byte, n))
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package num
+
+import (
+	"fmt"
+)
+
+// U128Clamped is a U128 that clamps out-of-range values when it is scanned or
+// unmarshalled instead of returning ErrRange. Values above MaxU128 become
+// MaxU128, negative values become 0.
+//
+// It is intended for use as a struct field or scan destination where the
+// input is known to be untrustworthy but a best-effort value is preferable
+// to an error:
+//
+//	var v struct{ N num.U128Clamped }
+//	err := json.Unmarshal([]byte(`{"N": "-1"}`), &v)
+//	u := v.N.U128() // 0
+//
+// Syntax errors are still reported.
+type U128Clamped U128
+
+// U128 returns the clamped value as a U128.
+func (u U128Clamped) U128() U128 { return U128(u) }
+
+func (u U128Clamped) String() string { return U128(u).String() }
+
+func (u U128Clamped) Format(s fmt.State, c rune) { U128(u).Format(s, c) }
+
+func (u *U128Clamped) Scan(state fmt.ScanState, verb rune) error {
+	return (*U128)(u).scan("U128Clamped.Scan", state, verb, true)
+}
+
+func (u U128Clamped) MarshalText() ([]byte, error) { return U128(u).MarshalText() }
+
+func (u *U128Clamped) UnmarshalText(bts []byte) (err error) {
+	return (*U128)(u).unmarshalText("U128Clamped.UnmarshalText", bts, true)
+}
+
+func (u U128Clamped) MarshalJSON() ([]byte, error) { return U128(u).MarshalJSON() }
+
+func (u *U128Clamped) UnmarshalJSON(bts []byte) (err error) {
+	return (*U128)(u).unmarshalJSON("U128Clamped.UnmarshalJSON", bts, true)
+}
+
+// I128Clamped is an I128 that clamps out-of-range values when it is scanned or
+// unmarshalled instead of returning ErrRange. Values above MaxI128 become
+// MaxI128, values below MinI128 become MinI128.
+//
+// Syntax errors are still reported. See U128Clamped for an example.
+type I128Clamped I128
+
+// I128 returns the clamped value as an I128.
+func (i I128Clamped) I128() I128 { return I128(i) }
+
+func (i I128Clamped) String() string { return I128(i).String() }
+
+func (i I128Clamped) Format(s fmt.State, c rune) { I128(i).Format(s, c) }
+
+func (i *I128Clamped) Scan(state fmt.ScanState, verb rune) error {
+	return (*I128)(i).scan("I128Clamped.Scan", state, verb, true)
+}
+
+func (i I128Clamped) MarshalText() ([]byte, error) { return I128(i).MarshalText() }
+
+func (i *I128Clamped) UnmarshalText(bts []byte) (err error) {
+	return (*I128)(i).unmarshalText("I128Clamped.UnmarshalText", bts, true)
+}
+
+func (i I128Clamped) MarshalJSON() ([]byte, error) { return I128(i).MarshalJSON() }
+
+func (i *I128Clamped) UnmarshalJSON(bts []byte) (err error) {
+	return (*I128)(i).unmarshalJSON("I128Clamped.UnmarshalJSON", bts, true)
+}
@@ -0,0 +1,161 @@
+package num
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+var u128RangeCases = []struct {
+	in      string
+	out     U128 // expected value if in range
+	inRange bool
+	clamped U128
+}{
+	{"0", zeroU128, true, zeroU128},
+	{"-0", zeroU128, true, zeroU128},
+	{"1", u64(1), true, u64(1)},
+	{"-1", zeroU128, false, zeroU128},
+	{"-340282366920938463463374607431768211456", zeroU128, false, zeroU128},
+	{"18446744073709551615", u64(maxUint64), true, u64(maxUint64)},
+	{"18446744073709551616", u128s("0x10000000000000000"), true, u128s("0x10000000000000000")},
+	{"340282366920938463463374607431768211454", MaxU128.Dec(), true, MaxU128.Dec()},
+	{"340282366920938463463374607431768211455", MaxU128, true, MaxU128},
+	{"340282366920938463463374607431768211456", zeroU128, false, MaxU128},
+	{"1606938044258990275541962092341162602522202993782792835301376", zeroU128, false, MaxU128}, // 1<<200
+}
+
+var i128RangeCases = []struct {
+	in      string
+	out     I128
+	inRange bool
+	clamped I128
+}{
+	{"0", zeroI128, true, zeroI128},
+	{"-1", i64(-1), true, i64(-1)},
+	{"9223372036854775807", i64(maxInt64), true, i64(maxInt64)},
+	{"-9223372036854775808", i64(minInt64), true, i64(minInt64)},
+	{"170141183460469231731687303715884105726", MaxI128.Dec(), true, MaxI128.Dec()},
+	{"170141183460469231731687303715884105727", MaxI128, true, MaxI128},
+	{"170141183460469231731687303715884105728", zeroI128, false, MaxI128},
+	{"-170141183460469231731687303715884105727", MinI128.Inc(), true, MinI128.Inc()},
+	{"-170141183460469231731687303715884105728", MinI128, true, MinI128},
+	{"-170141183460469231731687303715884105729", zeroI128, false, MinI128},
+	{"340282366920938463463374607431768211456", zeroI128, false, MaxI128},
+	{"-340282366920938463463374607431768211456", zeroI128, false, MinI128},
+}
+
+func TestU128UnmarshalRange(t *testing.T) {
+	for idx, tc := range u128RangeCases {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			for _, in := range []struct {
+				kind string
+				fn   func(u *U128, uc *U128Clamped) (err, errc error)
+			}{
+				{"text", func(u *U128, uc *U128Clamped) (error, error) {
+					return u.UnmarshalText([]byte(tc.in)), uc.UnmarshalText([]byte(tc.in))
+				}},
+				{"jsonstr", func(u *U128, uc *U128Clamped) (error, error) {
+					return json.Unmarshal([]byte(`"`+tc.in+`"`), u), json.Unmarshal([]byte(`"`+tc.in+`"`), uc)
+				}},
+				{"jsonnum", func(u *U128, uc *U128Clamped) (error, error) {
+					return json.Unmarshal([]byte(tc.in), u), json.Unmarshal([]byte(tc.in), uc)
+				}},
+				{"scan", func(u *U128, uc *U128Clamped) (err, errc error) {
+					_, err = fmt.Sscan(tc.in, u)
+					_, errc = fmt.Sscan(tc.in, uc)
+					return err, errc
+				}},
+			} {
+				t.Run(in.kind, func(t *testing.T) {
+					tt := assert.WrapTB(t)
+					var u U128
+					var uc U128Clamped
+					err, errc := in.fn(&u, &uc)
+					tt.MustOK(errc)
+					tt.MustEqual(tc.clamped, uc.U128())
+					if tc.inRange {
+						tt.MustOK(err)
+						tt.MustEqual(tc.out, u)
+					} else {
+						tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+						tt.MustEqual(zeroU128, u)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestI128UnmarshalRange(t *testing.T) {
+	for idx, tc := range i128RangeCases {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			for _, in := range []struct {
+				kind string
+				fn   func(i *I128, ic *I128Clamped) (err, errc error)
+			}{
+				{"text", func(i *I128, ic *I128Clamped) (error, error) {
+					return i.UnmarshalText([]byte(tc.in)), ic.UnmarshalText([]byte(tc.in))
+				}},
+				{"jsonstr", func(i *I128, ic *I128Clamped) (error, error) {
+					return json.Unmarshal([]byte(`"`+tc.in+`"`), i), json.Unmarshal([]byte(`"`+tc.in+`"`), ic)
+				}},
+				{"jsonnum", func(i *I128, ic *I128Clamped) (error, error) {
+					return json.Unmarshal([]byte(tc.in), i), json.Unmarshal([]byte(tc.in), ic)
+				}},
+				{"scan", func(i *I128, ic *I128Clamped) (err, errc error) {
+					_, err = fmt.Sscan(tc.in, i)
+					_, errc = fmt.Sscan(tc.in, ic)
+					return err, errc
+				}},
+			} {
+				t.Run(in.kind, func(t *testing.T) {
+					tt := assert.WrapTB(t)
+					var i I128
+					var ic I128Clamped
+					err, errc := in.fn(&i, &ic)
+					tt.MustOK(errc)
+					tt.MustEqual(tc.clamped, ic.I128())
+					if tc.inRange {
+						tt.MustOK(err)
+						tt.MustEqual(tc.out, i)
+					} else {
+						tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+						tt.MustEqual(zeroI128, i)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestClampedMarshal(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	type encoded struct {
+		U U128Clamped
+		I I128Clamped
+	}
+	v := encoded{U: U128Clamped(MaxU128), I: I128Clamped(MinI128)}
+	bts, err := json.Marshal(v)
+	tt.MustOK(err)
+	tt.MustEqual(`{"U":"340282366920938463463374607431768211455","I":"-170141183460469231731687303715884105728"}`, string(bts))
+
+	var out encoded
+	tt.MustOK(json.Unmarshal(bts, &out))
+	tt.MustEqual(v, out)
+
+	tt.MustEqual("ff", fmt.Sprintf("%x", U128Clamped(u64(255))))
+	tt.MustEqual("-5", fmt.Sprint(I128Clamped(i64(-5))))
+}
+
+func TestClampedSyntaxError(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var u U128Clamped
+	tt.MustAssert(errors.Is(u.UnmarshalText([]byte("x")), ErrSyntax))
+	var i I128Clamped
+	tt.MustAssert(errors.Is(i.UnmarshalJSON([]byte(`"x"`)), ErrSyntax))
+}
@@ -0,0 +1,297 @@
+package num
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// Decimal128 is an IEEE 754-2008 decimal128 floating point number in the
+// binary integer decimal (BID) encoding, as used by BSON and MongoDB.
+//
+// A finite Decimal128 is a coefficient of up to 34 decimal digits multiplied
+// by a power of ten between 10^-6176 and 10^6111. Decimal128 provides
+// conversions to and from an I128 coefficient and exponent; it does not
+// implement decimal arithmetic.
+type Decimal128 struct {
+	hi, lo uint64
+}
+
+const (
+	decimal128Bias   = 6176
+	decimal128MinExp = -6176
+	decimal128MaxExp = 6111
+
+	decimal128SignBit = 1 << 63
+
+	// If the two bits after the sign are both set, the coefficient is stored
+	// in a different position, or the value is NaN or infinity:
+	decimal128Combination = 3 << 61
+	decimal128Special     = 0xf << 59
+	decimal128Inf         = 0xf << 59
+	decimal128NaN         = 0x1f << 58
+)
+
+var (
+	// decimal128MaxCoef is the largest coefficient, 10^34 - 1. Larger
+	// coefficients are non-canonical and treated as zero.
+	decimal128MaxCoef = U128{hi: 0x1ed09bead87c0, lo: 0x378d8e63ffffffff}
+
+	errDecimal128NaN = fmt.Errorf("%w: Decimal128 is NaN", ErrRange)
+	errDecimal128Inf = fmt.Errorf("%w: Decimal128 is infinite", ErrRange)
+)
+
+// Decimal128FromRaw creates a Decimal128 from its 128-bit BID encoding, split
+// into the high and low 64 bits.
+func Decimal128FromRaw(hi, lo uint64) Decimal128 { return Decimal128{hi: hi, lo: lo} }
+
+// Raw returns the high and low 64 bits of the BID encoding of d.
+func (d Decimal128) Raw() (hi, lo uint64) { return d.hi, d.lo }
+
+// Decimal128NaN returns a quiet NaN.
+func Decimal128NaN() Decimal128 { return Decimal128{hi: decimal128NaN} }
+
+// Decimal128Inf returns positive infinity if sign >= 0, negative infinity if
+// sign < 0.
+func Decimal128Inf(sign int) Decimal128 {
+	if sign < 0 {
+		return Decimal128{hi: decimal128SignBit | decimal128Inf}
+	}
+	return Decimal128{hi: decimal128Inf}
+}
+
+// Decimal128FromI128 creates a Decimal128 with the value coef * 10^exp.
+//
+// If coef has more than 34 digits, or exp is below the minimum exponent, the
+// coefficient is rounded to fit using round-half-to-even and exact is false.
+// If exp is above the maximum exponent, the coefficient is padded with zeros
+// to bring it into range if possible; otherwise err is a *NumError wrapping
+// ErrRange.
+func Decimal128FromI128(coef I128, exp int) (d Decimal128, exact bool, err error) {
+	inExp := exp
+	neg := coef.Sign() < 0
+	mag := coef.AbsU128()
+	exact = true
+
+	if !mag.IsZero() && exp < decimal128MinExp-maxU128Digits {
+		// Every digit would be rounded away:
+		mag, exp, exact = zeroU128, decimal128MinExp, false
+	}
+
+	var roundDigit uint64
+	sticky := false
+	for mag.GreaterThan(decimal128MaxCoef) || exp < decimal128MinExp {
+		if mag.IsZero() {
+			// Any pending digit is now further down than the first digit
+			// rounded away, so it can only break a tie:
+			sticky = sticky || roundDigit != 0
+			roundDigit = 0
+			exp = decimal128MinExp
+			break
+		}
+		var r U128
+		sticky = sticky || roundDigit != 0
+		mag, r = mag.QuoRem64(10)
+		roundDigit = r.lo
+		exp++
+		exact = exact && roundDigit == 0 && !sticky
+	}
+	if roundDigit > 5 || (roundDigit == 5 && (sticky || mag.lo&1 == 1)) {
+		mag = mag.Inc()
+		if mag.GreaterThan(decimal128MaxCoef) {
+			mag = mag.Quo64(10)
+			exp++
+		}
+	}
+
+	for exp > decimal128MaxExp {
+		if mag.IsZero() {
+			exp = decimal128MaxExp
+			break
+		}
+		next, ok := mulAdd64(mag, 10, 0)
+		if !ok || next.GreaterThan(decimal128MaxCoef) {
+			return d, false, rangeError("Decimal128FromI128", coef.String()+"E"+strconv.Itoa(inExp))
+		}
+		mag = next
+		exp--
+	}
+
+	d.hi = uint64(exp+decimal128Bias)<<49 | mag.hi
+	d.lo = mag.lo
+	if neg {
+		d.hi |= decimal128SignBit
+	}
+	return d, exact, nil
+}
+
+// IsNaN reports whether d is a quiet or signalling NaN.
+func (d Decimal128) IsNaN() bool {
+	return d.hi&decimal128NaN == decimal128NaN
+}
+
+// IsInf reports whether d is an infinity, according to sign. If sign > 0,
+// IsInf reports whether d is positive infinity. If sign < 0, IsInf reports
+// whether d is negative infinity. If sign == 0, IsInf reports whether d is
+// either infinity.
+func (d Decimal128) IsInf(sign int) bool {
+	if d.hi&decimal128NaN != decimal128Inf {
+		return false
+	}
+	neg := d.hi&decimal128SignBit != 0
+	return sign == 0 || (sign > 0 && !neg) || (sign < 0 && neg)
+}
+
+// IsCanonical reports whether the coefficient of d is encoded canonically.
+// Finite values whose stored coefficient exceeds 10^34-1 are non-canonical
+// and are interpreted as zero. NaN and infinity are always considered
+// canonical.
+func (d Decimal128) IsCanonical() bool {
+	if d.hi&decimal128Special == decimal128Special {
+		return true
+	}
+	_, ok := d.coefficient()
+	return ok
+}
+
+// Signbit reports whether the sign bit of d is set, which is the case for
+// negative numbers and negative zero.
+func (d Decimal128) Signbit() bool {
+	return d.hi&decimal128SignBit != 0
+}
+
+// coefficient returns the magnitude of the coefficient of a finite d. If the
+// encoded coefficient is non-canonical, ok is false and coef is zero.
+func (d Decimal128) coefficient() (coef U128, ok bool) {
+	if d.hi&decimal128Combination == decimal128Combination {
+		// The implicit leading bits are 100, so the coefficient is at least
+		// 2^113, which is always larger than the maximum:
+		return zeroU128, false
+	}
+	coef = U128{hi: d.hi & (1<<49 - 1), lo: d.lo}
+	if coef.GreaterThan(decimal128MaxCoef) {
+		return zeroU128, false
+	}
+	return coef, true
+}
+
+func (d Decimal128) exponent() int {
+	if d.hi&decimal128Combination == decimal128Combination {
+		return int((d.hi>>47)&0x3fff) - decimal128Bias
+	}
+	return int((d.hi>>49)&0x3fff) - decimal128Bias
+}
+
+// Parts returns the coefficient and exponent of d, such that its value is
+// coef * 10^exp. Non-canonical coefficients are returned as zero. The sign of
+// negative zero is lost.
+//
+// If d is NaN or infinite, err is a *NumError wrapping ErrRange.
+func (d Decimal128) Parts() (coef I128, exp int, err error) {
+	neg, mag, exp, err := d.parts("Decimal128.Parts")
+	if err != nil {
+		return coef, 0, err
+	}
+	coef = mag.AsI128()
+	if neg {
+		coef = coef.Neg()
+	}
+	return coef, exp, nil
+}
+
+func (d Decimal128) parts(fn string) (neg bool, mag U128, exp int, err error) {
+	if d.IsNaN() {
+		return false, mag, 0, &NumError{Func: fn, Num: d.String(), Err: errDecimal128NaN}
+	} else if d.IsInf(0) {
+		return false, mag, 0, &NumError{Func: fn, Num: d.String(), Err: errDecimal128Inf}
+	}
+	mag, _ = d.coefficient()
+	return d.Signbit(), mag, d.exponent(), nil
+}
+
+// I128 returns the integer value of d. If d has a fractional part, it is
+// truncated towards zero and exact is false.
+//
+// If d is NaN or infinite, or its integer value does not fit in an I128, err
+// is a *NumError wrapping ErrRange.
+func (d Decimal128) I128() (v I128, exact bool, err error) {
+	neg, mag, exp, err := d.parts("Decimal128.I128")
+	if err != nil {
+		return v, false, err
+	}
+
+	exact = true
+	if exp < 0 {
+		for ; exp < 0 && !mag.IsZero(); exp++ {
+			var r U128
+			mag, r = mag.QuoRem64(10)
+			exact = exact && r.IsZero()
+		}
+	} else {
+		for ok := true; exp > 0 && !mag.IsZero(); exp-- {
+			if mag, ok = mulAdd64(mag, 10, 0); !ok {
+				return v, false, rangeError("Decimal128.I128", d.String())
+			}
+		}
+	}
+
+	v, inRange := i128FromLiteral(neg, mag, true)
+	if !inRange {
+		return zeroI128, false, rangeError("Decimal128.I128", d.String())
+	}
+	return v, exact, nil
+}
+
+// String formats d as its coefficient and exponent, i.e. "-12345E-2", or as
+// "NaN", "Inf" or "-Inf". It does not produce the scientific notation used by
+// the IEEE 754 to-sci-string operation.
+func (d Decimal128) String() string {
+	switch {
+	case d.IsNaN():
+		return "NaN"
+	case d.IsInf(1):
+		return "Inf"
+	case d.IsInf(-1):
+		return "-Inf"
+	}
+	mag, _ := d.coefficient()
+	s := mag.String() + "E" + strconv.Itoa(d.exponent())
+	if d.Signbit() {
+		s = "-" + s
+	}
+	return s
+}
+
+// PutBigEndian writes the 16 byte big-endian BID encoding of d into b.
+// len(b) must be >= 16.
+func (d Decimal128) PutBigEndian(b []byte) {
+	U128{hi: d.hi, lo: d.lo}.PutBigEndian(b)
+}
+
+// PutLittleEndian writes the 16 byte little-endian BID encoding of d into b,
+// which is the layout used by BSON. len(b) must be >= 16.
+func (d Decimal128) PutLittleEndian(b []byte) {
+	U128{hi: d.hi, lo: d.lo}.PutLittleEndian(b)
+}
+
+// Decimal128FromBigEndian decodes the first 16 bytes of b as a big-endian
+// BID encoded Decimal128. If len(b) < 16, err is a *NumError wrapping
+// ErrSyntax.
+func Decimal128FromBigEndian(b []byte) (d Decimal128, err error) {
+	if len(b) < 16 {
+		return d, syntaxError("Decimal128FromBigEndian", hex.EncodeToString(b))
+	}
+	u := MustU128FromBigEndian(b)
+	return Decimal128{hi: u.hi, lo: u.lo}, nil
+}
+
+// Decimal128FromLittleEndian decodes the first 16 bytes of b as a
+// little-endian BID encoded Decimal128, as stored in BSON. If len(b) < 16,
+// err is a *NumError wrapping ErrSyntax.
+func Decimal128FromLittleEndian(b []byte) (d Decimal128, err error) {
+	if len(b) < 16 {
+		return d, syntaxError("Decimal128FromLittleEndian", hex.EncodeToString(b))
+	}
+	u := MustU128FromLittleEndian(b)
+	return Decimal128{hi: u.hi, lo: u.lo}, nil
+}
@@ -0,0 +1,236 @@
+package num
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestDecimal128FromI128(t *testing.T) {
+	pow34 := MustU128FromString("10000000000000000000000000000000000").AsI128()
+	pow33 := MustU128FromString("1000000000000000000000000000000000").AsI128()
+
+	for idx, tc := range []struct {
+		coef    I128
+		exp     int
+		outCoef I128
+		outExp  int
+		exact   bool
+		raw     string // big-endian, or "" to skip
+	}{
+		{zeroI128, 0, zeroI128, 0, true, "30400000000000000000000000000000"},
+		{i64(1), 0, i64(1), 0, true, "30400000000000000000000000000001"},
+		{i64(10), -1, i64(10), -1, true, "303e000000000000000000000000000a"},
+		{i64(-12345), -2, i64(-12345), -2, true, "b03c0000000000000000000000003039"},
+		{i64(1), -6176, i64(1), -6176, true, "00000000000000000000000000000001"},
+		{pow34.Sub64(1), 6111, pow34.Sub64(1), 6111, true, "5fffed09bead87c0378d8e63ffffffff"},
+
+		// Too many digits, rounded half to even:
+		{MaxI128, 0, MustI128FromString("1701411834604692317316873037158841"), 5, false, ""},
+		{MinI128, 0, MustI128FromString("-1701411834604692317316873037158841"), 5, false, ""},
+		{pow34.Add64(5), 0, pow33, 1, false, ""},
+		{pow34.Add64(15), 0, pow33.Add64(2), 1, false, ""},
+		{pow34.Add64(16), 0, pow33.Add64(2), 1, false, ""},
+		{pow34.Add64(10), 0, pow33.Add64(1), 1, true, ""},
+		{pow34.Mul64(10).Sub64(1), 0, pow33, 2, false, ""},
+
+		// Exponent too small, rounded:
+		{i64(1), -6177, zeroI128, -6176, false, ""},
+		{i64(15), -6177, i64(2), -6176, false, ""},
+		{i64(25), -6177, i64(2), -6176, false, ""},
+		{i64(-26), -6177, i64(-3), -6176, false, ""},
+		{i64(1), -100000, zeroI128, -6176, false, ""},
+		{i64(7), -6178, zeroI128, -6176, false, ""},
+		{i64(6), -6178, zeroI128, -6176, false, ""},
+		{i64(-9), -6178, zeroI128, -6176, false, ""},
+		{i64(97), -6185, zeroI128, -6176, false, ""},
+		{i64(838), -6191, zeroI128, -6176, false, ""},
+		{i64(945734), -6187, zeroI128, -6176, false, ""},
+		{i64(51), -6178, i64(1), -6176, false, ""},
+		{i64(50), -6178, zeroI128, -6176, false, ""},
+		{zeroI128, -100000, zeroI128, -6176, true, ""},
+
+		// Exponent too large, clamped:
+		{i64(1), 6112, i64(10), 6111, true, ""},
+		{i64(1), 6111 + 33, pow33, 6111, true, ""},
+		{zeroI128, 100000, zeroI128, 6111, true, ""},
+	} {
+		t.Run(fmt.Sprintf("%d/%sE%d", idx, tc.coef, tc.exp), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			d, exact, err := Decimal128FromI128(tc.coef, tc.exp)
+			tt.MustOK(err)
+			tt.MustEqual(tc.exact, exact)
+
+			coef, exp, err := d.Parts()
+			tt.MustOK(err)
+			tt.MustEqual(tc.outCoef, coef)
+			tt.MustEqual(tc.outExp, exp)
+			tt.MustAssert(d.IsCanonical())
+
+			if tc.raw != "" {
+				var b [16]byte
+				d.PutBigEndian(b[:])
+				tt.MustEqual(tc.raw, hex.EncodeToString(b[:]))
+			}
+		})
+	}
+}
+
+func TestDecimal128FromI128Overflow(t *testing.T) {
+	for idx, tc := range []struct {
+		coef I128
+		exp  int
+		num  string
+	}{
+		{i64(1), 6111 + 34, "1E6145"},
+		{i64(1), 100000, "1E100000"},
+		{i64(7), 6200, "7E6200"},
+		{i64(-7), 6200, "-7E6200"},
+
+		// Rounding up to 10^34 at the maximum exponent overflows:
+		{MustI128FromString("99999999999999999999999999999999999"), 6110, "99999999999999999999999999999999999E6110"},
+	} {
+		t.Run(fmt.Sprintf("%d/%sE%d", idx, tc.coef, tc.exp), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			_, _, err := Decimal128FromI128(tc.coef, tc.exp)
+			tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+
+			var nerr *NumError
+			tt.MustAssert(errors.As(err, &nerr), "%v", err)
+			tt.MustEqual("Decimal128FromI128", nerr.Func)
+			tt.MustEqual(tc.num, nerr.Num)
+		})
+	}
+}
+
+func TestDecimal128Special(t *testing.T) {
+	for idx, tc := range []struct {
+		raw       string
+		nan       bool
+		inf       int
+		canonical bool
+		str       string
+	}{
+		{"7c000000000000000000000000000000", true, 0, true, "NaN"},
+		{"fc000000000000000000000000000000", true, 0, true, "NaN"},
+		{"7e000000000000000000000000000000", true, 0, true, "NaN"}, // signalling
+		{"78000000000000000000000000000000", false, 1, true, "Inf"},
+		{"f8000000000000000000000000000000", false, -1, true, "-Inf"},
+		{"b0400000000000000000000000000000", false, 0, true, "-0E0"},
+		{"3041ed09bead87c0378d8e6400000000", false, 0, false, "0E0"}, // coefficient 10^34
+		{"6c100000000000000000000000000000", false, 0, false, "0E0"}, // implicit 100 prefix
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.raw), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			b, _ := hex.DecodeString(tc.raw)
+			d, err := Decimal128FromBigEndian(b)
+			tt.MustOK(err)
+
+			tt.MustEqual(tc.nan, d.IsNaN())
+			tt.MustEqual(tc.inf != 0, d.IsInf(0))
+			tt.MustEqual(tc.inf > 0, d.IsInf(1))
+			tt.MustEqual(tc.inf < 0, d.IsInf(-1))
+			tt.MustEqual(tc.canonical, d.IsCanonical())
+			tt.MustEqual(tc.str, d.String())
+
+			_, _, err = d.Parts()
+			_, _, ierr := d.I128()
+			if tc.nan || tc.inf != 0 {
+				tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+				tt.MustAssert(errors.Is(ierr, ErrRange), "%v", ierr)
+			} else {
+				tt.MustOK(err)
+				tt.MustOK(ierr)
+			}
+		})
+	}
+
+	tt := assert.WrapTB(t)
+	tt.MustAssert(Decimal128NaN().IsNaN())
+	tt.MustAssert(Decimal128Inf(1).IsInf(1))
+	tt.MustAssert(Decimal128Inf(-1).IsInf(-1))
+}
+
+func TestDecimal128I128(t *testing.T) {
+	for idx, tc := range []struct {
+		coef  I128
+		exp   int
+		out   I128
+		exact bool
+		err   error
+	}{
+		{i64(12345), -2, i64(123), false, nil},
+		{i64(-12345), -2, i64(-123), false, nil},
+		{i64(12300), -2, i64(123), true, nil},
+		{i64(1), -6176, zeroI128, false, nil},
+		{zeroI128, 6111, zeroI128, true, nil},
+		{i64(1), 38, MustI128FromString("100000000000000000000000000000000000000"), true, nil},
+		{i64(-17), 37, MustI128FromString("-170000000000000000000000000000000000000"), true, nil},
+		{i64(2), 38, zeroI128, false, ErrRange},
+		{i64(1), 39, zeroI128, false, ErrRange},
+		{i64(1), 6111, zeroI128, false, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%sE%d", idx, tc.coef, tc.exp), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			d, _, err := Decimal128FromI128(tc.coef, tc.exp)
+			tt.MustOK(err)
+
+			v, exact, err := d.I128()
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, v)
+			tt.MustEqual(tc.exact, exact)
+		})
+	}
+}
+
+func TestDecimal128LittleEndian(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	// From the BSON corpus, "1.0":
+	b, _ := hex.DecodeString("0a000000000000000000000000003e30")
+	d, err := Decimal128FromLittleEndian(b)
+	tt.MustOK(err)
+	coef, exp, err := d.Parts()
+	tt.MustOK(err)
+	tt.MustEqual(i64(10), coef)
+	tt.MustEqual(-1, exp)
+
+	var out [16]byte
+	d.PutLittleEndian(out[:])
+	tt.MustEqual(b, out[:])
+
+	_, err = Decimal128FromLittleEndian(b[:15])
+	tt.MustAssert(errors.Is(err, ErrSyntax))
+}
+
+func TestDecimal128RoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		coef := randI128(scratch)
+		if i%2 == 0 {
+			coef = coef.Neg()
+		}
+		// Up to 5 digits may be rounded away, which increases the exponent:
+		exp := globalRNG.Intn(decimal128MaxExp-decimal128MinExp-5) + decimal128MinExp
+		d, exact, err := Decimal128FromI128(coef, exp)
+		tt.MustOK(err)
+
+		rcoef, rexp, err := d.Parts()
+		tt.MustOK(err)
+		if exact {
+			tt.MustEqual(coef.Cmp(zeroI128) == 0, rcoef.Cmp(zeroI128) == 0)
+			for ; rexp > exp; rexp-- {
+				rcoef = rcoef.Mul(i64(10))
+			}
+			tt.MustEqual(coef, rcoef)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package num
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// MaxDecimal128Precision is the largest precision, in decimal digits, that
+// Arrow and Parquet permit for a 128-bit decimal column.
+const MaxDecimal128Precision = 38
+
+// pow10U128 holds 10^0 to 10^38, which are all the powers of 10 that fit in a
+// U128.
+var pow10U128 = func() (out [maxU128Digits]U128) {
+	out[0] = U128From64(1)
+	for i := 1; i < len(out); i++ {
+		out[i], _ = mulAdd64(out[i-1], 10, 0)
+	}
+	return out
+}()
+
+// EncodeArrowDecimal128 appends the values in src to dst in the layout of an
+// Apache Arrow Decimal128 column buffer, which is a 16 byte little-endian
+// two's complement integer per value, and returns the extended buffer.
+//
+// Each value must have no more than precision decimal digits, which must be
+// between 1 and MaxDecimal128Precision. The scale does not affect the
+// encoding, so it is not required.
+func EncodeArrowDecimal128(dst []byte, src []I128, precision int) ([]byte, error) {
+	return encodeDecimal128("EncodeArrowDecimal128", LittleEndian, dst, src, precision)
+}
+
+// DecodeArrowDecimal128 appends the values in an Apache Arrow Decimal128
+// column buffer to dst and returns the extended slice. Values are validated
+// against precision as they are in EncodeArrowDecimal128.
+func DecodeArrowDecimal128(dst []I128, src []byte, precision int) ([]I128, error) {
+	return decodeDecimal128("DecodeArrowDecimal128", LittleEndian, dst, src, precision)
+}
+
+// EncodeParquetDecimal128 appends the values in src to dst as Parquet
+// FIXED_LEN_BYTE_ARRAY(16) DECIMAL values, which are 16 byte big-endian
+// two's complement integers, and returns the extended buffer.
+//
+// Each value must have no more than precision decimal digits, which must be
+// between 1 and MaxDecimal128Precision.
+func EncodeParquetDecimal128(dst []byte, src []I128, precision int) ([]byte, error) {
+	return encodeDecimal128("EncodeParquetDecimal128", BigEndian, dst, src, precision)
+}
+
+// DecodeParquetDecimal128 appends the values in a buffer of Parquet
+// FIXED_LEN_BYTE_ARRAY(16) DECIMAL values to dst and returns the extended
+// slice. Values are validated against precision as they are in
+// EncodeParquetDecimal128.
+func DecodeParquetDecimal128(dst []I128, src []byte, precision int) ([]I128, error) {
+	return decodeDecimal128("DecodeParquetDecimal128", BigEndian, dst, src, precision)
+}
+
+func encodeDecimal128(fn string, order ByteOrder, dst []byte, src []I128, precision int) ([]byte, error) {
+	if err := checkDecimal128Precision(fn, precision); err != nil {
+		return dst, err
+	}
+	limit := pow10U128[precision]
+
+	start := len(dst)
+	for idx, v := range src {
+		if !v.AbsU128().LessThan(limit) {
+			return dst[:start], decimal128PrecisionError(fn, v, idx, precision)
+		}
+		dst = order.AppendI128(dst, v)
+	}
+	return dst, nil
+}
+
+func decodeDecimal128(fn string, order ByteOrder, dst []I128, src []byte, precision int) ([]I128, error) {
+	if err := checkDecimal128Precision(fn, precision); err != nil {
+		return dst, err
+	}
+	if len(src)%16 != 0 {
+		return dst, &NumError{
+			Func: fn,
+			Num:  hex.EncodeToString(src[len(src)/16*16:]),
+			Err:  fmt.Errorf("%w: length %d is not a multiple of 16", ErrSyntax, len(src)),
+		}
+	}
+	limit := pow10U128[precision]
+
+	start := len(dst)
+	for idx := 0; idx < len(src)/16; idx++ {
+		v := order.I128(src[idx*16:])
+		if !v.AbsU128().LessThan(limit) {
+			return dst[:start], decimal128PrecisionError(fn, v, idx, precision)
+		}
+		dst = append(dst, v)
+	}
+	return dst, nil
+}
+
+func checkDecimal128Precision(fn string, precision int) error {
+	if precision < 1 || precision > MaxDecimal128Precision {
+		return fmt.Errorf("num: %s: precision %d out of range [1, %d]", fn, precision, MaxDecimal128Precision)
+	}
+	return nil
+}
+
+func decimal128PrecisionError(fn string, v I128, idx int, precision int) *NumError {
+	return &NumError{
+		Func: fn,
+		Num:  v.String(),
+		Err:  fmt.Errorf("%w: value at index %d exceeds precision %d", ErrRange, idx, precision),
+	}
+}
@@ -0,0 +1,124 @@
+package num
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestPow10U128(t *testing.T) {
+	tt := assert.WrapTB(t)
+	for i, v := range pow10U128 {
+		tt.MustEqual("1"+strings.Repeat("0", i), v.String())
+	}
+}
+
+func TestDecimal128Columns(t *testing.T) {
+	tt := assert.WrapTB(t)
+	vals := []I128{zeroI128, i64(1), i64(-1), i64(0x0102)}
+
+	arrow, err := EncodeArrowDecimal128([]byte{0xaa}, vals, 5)
+	tt.MustOK(err)
+	tt.MustEqual(""+
+		"aa"+
+		"00000000000000000000000000000000"+
+		"01000000000000000000000000000000"+
+		"ffffffffffffffffffffffffffffffff"+
+		"02010000000000000000000000000000",
+		hex.EncodeToString(arrow))
+
+	parquet, err := EncodeParquetDecimal128(nil, vals, 5)
+	tt.MustOK(err)
+	tt.MustEqual(""+
+		"00000000000000000000000000000000"+
+		"00000000000000000000000000000001"+
+		"ffffffffffffffffffffffffffffffff"+
+		"00000000000000000000000000000102",
+		hex.EncodeToString(parquet))
+
+	out, err := DecodeArrowDecimal128([]I128{i64(7)}, arrow[1:], 5)
+	tt.MustOK(err)
+	tt.MustEqual(append([]I128{i64(7)}, vals...), out)
+
+	out, err = DecodeParquetDecimal128(nil, parquet, 5)
+	tt.MustOK(err)
+	tt.MustEqual(vals, out)
+}
+
+func TestDecimal128ColumnPrecision(t *testing.T) {
+	max38 := MustI128FromString("99999999999999999999999999999999999999")
+
+	for idx, tc := range []struct {
+		in        I128
+		precision int
+		ok        bool
+	}{
+		{i64(9), 1, true},
+		{i64(-9), 1, true},
+		{i64(10), 1, false},
+		{i64(-10), 1, false},
+		{i64(99999), 5, true},
+		{i64(100000), 5, false},
+		{max38, 38, true},
+		{max38.Neg(), 38, true},
+		{max38.Add64(1), 38, false},
+		{MaxI128, 38, false},
+		{MinI128, 38, false},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%d", idx, tc.in, tc.precision), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			vals := []I128{zeroI128, tc.in}
+
+			for _, enc := range []func([]byte, []I128, int) ([]byte, error){EncodeArrowDecimal128, EncodeParquetDecimal128} {
+				out, err := enc([]byte{0xaa}, vals, tc.precision)
+				if tc.ok {
+					tt.MustOK(err)
+					tt.MustEqual(33, len(out))
+				} else {
+					tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+					tt.MustEqual([]byte{0xaa}, out)
+				}
+			}
+
+			for _, order := range []ByteOrder{LittleEndian, BigEndian} {
+				src := order.AppendI128(nil, zeroI128)
+				src = order.AppendI128(src, tc.in)
+				var out []I128
+				var err error
+				if order == LittleEndian {
+					out, err = DecodeArrowDecimal128(nil, src, tc.precision)
+				} else {
+					out, err = DecodeParquetDecimal128(nil, src, tc.precision)
+				}
+				if tc.ok {
+					tt.MustOK(err)
+					tt.MustEqual(vals, out)
+				} else {
+					tt.MustAssert(errors.Is(err, ErrRange), "%v", err)
+					tt.MustEqual(0, len(out))
+				}
+			}
+		})
+	}
+}
+
+func TestDecimal128ColumnErrors(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	for _, p := range []int{-1, 0, 39} {
+		_, err := EncodeArrowDecimal128(nil, nil, p)
+		tt.MustAssert(err != nil)
+		_, err = DecodeParquetDecimal128(nil, nil, p)
+		tt.MustAssert(err != nil)
+	}
+
+	_, err := DecodeArrowDecimal128(nil, make([]byte, 17), 38)
+	tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+
+	_, err = EncodeParquetDecimal128(nil, []I128{i64(1), i64(100), i64(2)}, 2)
+	tt.MustEqual(`num: EncodeParquetDecimal128: parsing "100": value out of range: value at index 1 exceeds precision 2`, err.Error())
+}
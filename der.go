@@ -0,0 +1,157 @@
+package num
+
+import (
+	"encoding/hex"
+)
+
+const derTagInteger = 0x02
+
+// MarshalDER returns the ASN.1 DER encoding of u as an INTEGER. The contents
+// are the minimal two's complement representation, so a zero byte is
+// prepended if the most significant bit of u's minimal encoding is set.
+func (u U128) MarshalDER() ([]byte, error) {
+	return u.AppendDER(nil), nil
+}
+
+// AppendDER appends the ASN.1 DER encoding of u, as returned by MarshalDER,
+// to dst and returns the extended buffer.
+func (u U128) AppendDER(dst []byte) []byte {
+	n := u.byteLen()
+	if n == 0 || u.Bit(n*8-1) != 0 {
+		dst = append(dst, derTagInteger, byte(n+1), 0)
+	} else {
+		dst = append(dst, derTagInteger, byte(n))
+	}
+	return u.AppendBytes(dst)
+}
+
+// UnmarshalDER decodes an ASN.1 DER INTEGER from b, which must contain
+// exactly one encoded value. See U128FromDER for details.
+func (u *U128) UnmarshalDER(b []byte) error {
+	v, rest, err := u128FromDER("U128.UnmarshalDER", b)
+	if err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return syntaxError("U128.UnmarshalDER", hex.EncodeToString(b))
+	}
+	*u = v
+	return nil
+}
+
+// U128FromDER decodes an ASN.1 DER INTEGER from the start of b and returns it
+// along with the remaining bytes, in the style of
+// golang.org/x/crypto/cryptobyte.
+//
+// Encodings that are not valid DER, including those with non-minimal
+// contents or lengths, produce a *NumError wrapping ErrSyntax. Valid integers
+// that are negative or do not fit in a U128 produce a *NumError wrapping
+// ErrRange.
+func U128FromDER(b []byte) (out U128, rest []byte, err error) {
+	return u128FromDER("U128FromDER", b)
+}
+
+func u128FromDER(fn string, b []byte) (out U128, rest []byte, err error) {
+	content, rest, err := derInteger(fn, b)
+	if err != nil {
+		return out, b, err
+	}
+	if content[0]&0x80 != 0 {
+		return out, b, rangeError(fn, hex.EncodeToString(b))
+	}
+	out, err = U128FromBytes(content)
+	if err != nil {
+		return out, b, rangeError(fn, hex.EncodeToString(b))
+	}
+	return out, rest, nil
+}
+
+// MarshalDER returns the ASN.1 DER encoding of i as an INTEGER, using the
+// minimal two's complement representation returned by I128.Bytes.
+func (i I128) MarshalDER() ([]byte, error) {
+	return i.AppendDER(nil), nil
+}
+
+// AppendDER appends the ASN.1 DER encoding of i, as returned by MarshalDER,
+// to dst and returns the extended buffer.
+func (i I128) AppendDER(dst []byte) []byte {
+	dst = append(dst, derTagInteger, byte(i.byteLen()))
+	return i.AppendBytes(dst)
+}
+
+// UnmarshalDER decodes an ASN.1 DER INTEGER from b, which must contain
+// exactly one encoded value. See I128FromDER for details.
+func (i *I128) UnmarshalDER(b []byte) error {
+	v, rest, err := i128FromDER("I128.UnmarshalDER", b)
+	if err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return syntaxError("I128.UnmarshalDER", hex.EncodeToString(b))
+	}
+	*i = v
+	return nil
+}
+
+// I128FromDER decodes an ASN.1 DER INTEGER from the start of b and returns it
+// along with the remaining bytes, in the style of
+// golang.org/x/crypto/cryptobyte.
+//
+// Encodings that are not valid DER, including those with non-minimal
+// contents or lengths, produce a *NumError wrapping ErrSyntax. Valid integers
+// that do not fit in an I128 produce a *NumError wrapping ErrRange.
+func I128FromDER(b []byte) (out I128, rest []byte, err error) {
+	return i128FromDER("I128FromDER", b)
+}
+
+func i128FromDER(fn string, b []byte) (out I128, rest []byte, err error) {
+	content, rest, err := derInteger(fn, b)
+	if err != nil {
+		return out, b, err
+	}
+	out, err = I128FromBytes(content)
+	if err != nil {
+		return out, b, rangeError(fn, hex.EncodeToString(b))
+	}
+	return out, rest, nil
+}
+
+// derInteger splits the contents of the DER INTEGER at the start of b from
+// the bytes that follow it, checking that both the length and the contents
+// are minimally encoded. The contents are never empty.
+func derInteger(fn string, b []byte) (content, rest []byte, err error) {
+	if len(b) < 2 || b[0] != derTagInteger {
+		return nil, b, syntaxError(fn, hex.EncodeToString(b))
+	}
+
+	var length uint64
+	hdr := 2
+	if b[1]&0x80 == 0 {
+		length = uint64(b[1])
+	} else {
+		// Long form; indefinite lengths (0x80) and lengths with more than 8
+		// bytes are not valid here:
+		n := int(b[1] & 0x7f)
+		if n == 0 || n > 8 || len(b) < 2+n || b[2] == 0 {
+			return nil, b, syntaxError(fn, hex.EncodeToString(b))
+		}
+		for _, c := range b[2 : 2+n] {
+			length = length<<8 | uint64(c)
+		}
+		if length < 0x80 {
+			// Should have used the short form:
+			return nil, b, syntaxError(fn, hex.EncodeToString(b))
+		}
+		hdr += n
+	}
+
+	if length == 0 || uint64(len(b)-hdr) < length {
+		return nil, b, syntaxError(fn, hex.EncodeToString(b))
+	}
+	content, rest = b[hdr:hdr+int(length)], b[hdr+int(length):]
+
+	if len(content) > 1 &&
+		((content[0] == 0x00 && content[1]&0x80 == 0) ||
+			(content[0] == 0xff && content[1]&0x80 != 0)) {
+		return nil, b, syntaxError(fn, hex.EncodeToString(b))
+	}
+	return content, rest, nil
+}
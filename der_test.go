@@ -0,0 +1,167 @@
+package num
+
+import (
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128DER(t *testing.T) {
+	for idx, tc := range []struct {
+		in  U128
+		out string
+	}{
+		{zeroU128, "020100"},
+		{u64(1), "020101"},
+		{u64(0x7f), "02017f"},
+		{u64(0x80), "02020080"},
+		{u64(0x100), "02020100"},
+		{MaxU128, "021100ffffffffffffffffffffffffffffffff"},
+		{U128FromRaw(0x7fffffffffffffff, maxUint64), "02107fffffffffffffffffffffffffffffff"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, err := tc.in.MarshalDER()
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, hex.EncodeToString(bts))
+
+			ref, err := asn1.Marshal(tc.in.AsBigInt())
+			tt.MustOK(err)
+			tt.MustEqual(ref, bts)
+
+			var v U128
+			tt.MustOK(v.UnmarshalDER(bts))
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestI128DER(t *testing.T) {
+	for idx, tc := range []struct {
+		in  I128
+		out string
+	}{
+		{zeroI128, "020100"},
+		{i64(127), "02017f"},
+		{i64(128), "02020080"},
+		{i64(-1), "0201ff"},
+		{i64(-128), "020180"},
+		{i64(-129), "0202ff7f"},
+		{MaxI128, "02107fffffffffffffffffffffffffffffff"},
+		{MinI128, "021080000000000000000000000000000000"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, err := tc.in.MarshalDER()
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, hex.EncodeToString(bts))
+
+			ref, err := asn1.Marshal(tc.in.AsBigInt())
+			tt.MustOK(err)
+			tt.MustEqual(ref, bts)
+
+			var v I128
+			tt.MustOK(v.UnmarshalDER(bts))
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestDERRest(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	var buf []byte
+	buf = u64(1).AppendDER(buf)
+	buf = MaxU128.AppendDER(buf)
+	buf = append(buf, 0xaa)
+
+	v, rest, err := U128FromDER(buf)
+	tt.MustOK(err)
+	tt.MustEqual(u64(1), v)
+
+	v, rest, err = U128FromDER(rest)
+	tt.MustOK(err)
+	tt.MustEqual(MaxU128, v)
+	tt.MustEqual([]byte{0xaa}, rest)
+
+	var u U128
+	tt.MustAssert(errors.Is(u.UnmarshalDER(buf), ErrSyntax))
+
+	iv, rest, err := I128FromDER([]byte{0x02, 0x01, 0xff, 0x05, 0x00})
+	tt.MustOK(err)
+	tt.MustEqual(i64(-1), iv)
+	tt.MustEqual([]byte{0x05, 0x00}, rest)
+}
+
+func TestDERErrors(t *testing.T) {
+	for idx, tc := range []struct {
+		in   string
+		uErr error
+		iErr error
+	}{
+		{"", ErrSyntax, ErrSyntax},
+		{"02", ErrSyntax, ErrSyntax},
+		{"0200", ErrSyntax, ErrSyntax},     // empty contents
+		{"030100", ErrSyntax, ErrSyntax},   // wrong tag
+		{"020201", ErrSyntax, ErrSyntax},   // truncated
+		{"02020001", ErrSyntax, ErrSyntax}, // non-minimal positive
+		{"0202ff80", ErrSyntax, ErrSyntax}, // non-minimal negative
+		{"02810101", ErrSyntax, ErrSyntax}, // non-minimal length
+		{"028001", ErrSyntax, ErrSyntax},   // indefinite length
+		{"0201ff", ErrRange, nil},          // negative
+		{"021080000000000000000000000000000000", ErrRange, nil},
+		{"021100ffffffffffffffffffffffffffffffff", nil, ErrRange},
+		{"02110100000000000000000000000000000000", ErrRange, ErrRange},
+		{"021401" + "00000000000000000000000000000000000000", ErrRange, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, _ := hex.DecodeString(tc.in)
+
+			var u U128
+			err := u.UnmarshalDER(bts)
+			if tc.uErr != nil {
+				tt.MustAssert(errors.Is(err, tc.uErr), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+
+			var i I128
+			err = i.UnmarshalDER(bts)
+			if tc.iErr != nil {
+				tt.MustAssert(errors.Is(err, tc.iErr), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+		})
+	}
+}
+
+func TestDERRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randU128(scratch)
+		bts, _ := u.MarshalDER()
+		var ur U128
+		tt.MustOK(ur.UnmarshalDER(bts))
+		tt.MustEqual(u, ur)
+
+		v := randI128(scratch)
+		if i%2 == 0 {
+			v = v.Neg()
+		}
+		bts, _ = v.MarshalDER()
+		ref, err := asn1.Marshal(v.AsBigInt())
+		tt.MustOK(err)
+		tt.MustEqual(ref, bts)
+
+		var vr I128
+		tt.MustOK(vr.UnmarshalDER(bts))
+		tt.MustEqual(v, vr)
+	}
+}
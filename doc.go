@@ -40,11 +40,28 @@ U128 and I128 support the following formatting and marshalling interfaces:
 
 	- fmt.Formatter
 	- fmt.Stringer
+	- fmt.GoStringer
 	- fmt.Scanner
 	- json.Marshaler
 	- json.Unmarshaler
 	- encoding.TextMarshaler
 	- encoding.TextUnmarshaler
+	- encoding.BinaryMarshaler
+	- encoding.BinaryUnmarshaler
+	- gob.GobEncoder
+	- gob.GobDecoder
+	- driver.Valuer
+
+Unmarshalling a value that is out of range for the target type fails with
+ErrRange. U128Clamped and I128Clamped clamp out-of-range values instead.
+
+JSON is written as a quoted decimal string by default. U128Number and
+I128Number write bare JSON numbers, U128Hex and I128Hex write "0x"-prefixed
+hex strings. All of them accept any of these forms when unmarshalling.
+
+U128 and I128 can't implement sql.Scanner, as Scan is used for fmt.Scanner.
+Use U128Scanner and I128Scanner to scan into them from database/sql, or
+NullU128 and NullI128 for nullable columns.
 
 */
 package num
@@ -0,0 +1,135 @@
+package num
+
+import (
+	"encoding/hex"
+)
+
+// U128FromBigEndian decodes the first 16 bytes of b as a big-endian U128.
+// If len(b) < 16, err is a *NumError wrapping ErrSyntax.
+func U128FromBigEndian(b []byte) (out U128, err error) {
+	if len(b) < 16 {
+		return out, syntaxError("U128FromBigEndian", hex.EncodeToString(b))
+	}
+	return MustU128FromBigEndian(b), nil
+}
+
+// U128FromLittleEndian decodes the first 16 bytes of b as a little-endian
+// U128. If len(b) < 16, err is a *NumError wrapping ErrSyntax.
+func U128FromLittleEndian(b []byte) (out U128, err error) {
+	if len(b) < 16 {
+		return out, syntaxError("U128FromLittleEndian", hex.EncodeToString(b))
+	}
+	return MustU128FromLittleEndian(b), nil
+}
+
+// I128FromBigEndian decodes the first 16 bytes of b as a big-endian two's
+// complement I128. If len(b) < 16, err is a *NumError wrapping ErrSyntax.
+func I128FromBigEndian(b []byte) (out I128, err error) {
+	if len(b) < 16 {
+		return out, syntaxError("I128FromBigEndian", hex.EncodeToString(b))
+	}
+	return MustI128FromBigEndian(b), nil
+}
+
+// I128FromLittleEndian decodes the first 16 bytes of b as a little-endian
+// two's complement I128. If len(b) < 16, err is a *NumError wrapping
+// ErrSyntax.
+func I128FromLittleEndian(b []byte) (out I128, err error) {
+	if len(b) < 16 {
+		return out, syntaxError("I128FromLittleEndian", hex.EncodeToString(b))
+	}
+	return MustI128FromLittleEndian(b), nil
+}
+
+// AppendBigEndian appends the 16 byte big-endian encoding of u to dst and
+// returns the extended buffer.
+func (u U128) AppendBigEndian(dst []byte) []byte {
+	dst, b := grow16(dst)
+	u.PutBigEndian(b)
+	return dst
+}
+
+// AppendLittleEndian appends the 16 byte little-endian encoding of u to dst
+// and returns the extended buffer.
+func (u U128) AppendLittleEndian(dst []byte) []byte {
+	dst, b := grow16(dst)
+	u.PutLittleEndian(b)
+	return dst
+}
+
+// AppendBigEndian appends the 16 byte big-endian two's complement encoding of
+// i to dst and returns the extended buffer.
+func (i I128) AppendBigEndian(dst []byte) []byte {
+	dst, b := grow16(dst)
+	i.PutBigEndian(b)
+	return dst
+}
+
+// AppendLittleEndian appends the 16 byte little-endian two's complement
+// encoding of i to dst and returns the extended buffer.
+func (i I128) AppendLittleEndian(dst []byte) []byte {
+	dst, b := grow16(dst)
+	i.PutLittleEndian(b)
+	return dst
+}
+
+// grow16 extends dst by 16 bytes, returning the new slice and the 16 bytes
+// that were added.
+func grow16(dst []byte) (out []byte, added []byte) {
+	n := len(dst)
+	if cap(dst)-n < 16 {
+		out = make([]byte, n+16, 2*n+16)
+		copy(out, dst)
+	} else {
+		out = dst[:n+16]
+	}
+	return out, out[n:]
+}
+
+// ByteOrder specifies how to convert byte slices into U128 and I128 values,
+// in the same way as encoding/binary.ByteOrder does for the builtin integer
+// types. It allows the byte order to be chosen at runtime.
+//
+// As with encoding/binary, the decoding and Put methods panic if the slice
+// is shorter than 16 bytes.
+type ByteOrder interface {
+	U128(b []byte) U128
+	PutU128(b []byte, v U128)
+	AppendU128(dst []byte, v U128) []byte
+
+	I128(b []byte) I128
+	PutI128(b []byte, v I128)
+	AppendI128(dst []byte, v I128) []byte
+
+	String() string
+}
+
+var (
+	// BigEndian is the big-endian implementation of ByteOrder.
+	BigEndian ByteOrder = bigEndian{}
+
+	// LittleEndian is the little-endian implementation of ByteOrder.
+	LittleEndian ByteOrder = littleEndian{}
+)
+
+type bigEndian struct{}
+
+func (bigEndian) U128(b []byte) U128                   { return MustU128FromBigEndian(b) }
+func (bigEndian) PutU128(b []byte, v U128)             { v.PutBigEndian(b) }
+func (bigEndian) AppendU128(dst []byte, v U128) []byte { return v.AppendBigEndian(dst) }
+func (bigEndian) I128(b []byte) I128                   { return MustI128FromBigEndian(b) }
+func (bigEndian) PutI128(b []byte, v I128)             { v.PutBigEndian(b) }
+func (bigEndian) AppendI128(dst []byte, v I128) []byte { return v.AppendBigEndian(dst) }
+func (bigEndian) String() string                       { return "BigEndian" }
+func (bigEndian) GoString() string                     { return "num.BigEndian" }
+
+type littleEndian struct{}
+
+func (littleEndian) U128(b []byte) U128                   { return MustU128FromLittleEndian(b) }
+func (littleEndian) PutU128(b []byte, v U128)             { v.PutLittleEndian(b) }
+func (littleEndian) AppendU128(dst []byte, v U128) []byte { return v.AppendLittleEndian(dst) }
+func (littleEndian) I128(b []byte) I128                   { return MustI128FromLittleEndian(b) }
+func (littleEndian) PutI128(b []byte, v I128)             { v.PutLittleEndian(b) }
+func (littleEndian) AppendI128(dst []byte, v I128) []byte { return v.AppendLittleEndian(dst) }
+func (littleEndian) String() string                       { return "LittleEndian" }
+func (littleEndian) GoString() string                     { return "num.LittleEndian" }
@@ -0,0 +1,117 @@
+package num
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestByteOrderU128(t *testing.T) {
+	for idx, tc := range []struct {
+		order ByteOrder
+		in    U128
+		out   string
+	}{
+		{BigEndian, zeroU128, "00000000000000000000000000000000"},
+		{BigEndian, u64(1), "00000000000000000000000000000001"},
+		{BigEndian, U128FromRaw(0x0102030405060708, 0x090a0b0c0d0e0f10), "0102030405060708090a0b0c0d0e0f10"},
+		{LittleEndian, u64(1), "01000000000000000000000000000000"},
+		{LittleEndian, U128FromRaw(0x0102030405060708, 0x090a0b0c0d0e0f10), "100f0e0d0c0b0a090807060504030201"},
+		{LittleEndian, MaxU128, "ffffffffffffffffffffffffffffffff"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%s", idx, tc.order, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+
+			b := make([]byte, 16)
+			tc.order.PutU128(b, tc.in)
+			tt.MustEqual(tc.out, hex.EncodeToString(b))
+			tt.MustEqual(tc.in, tc.order.U128(b))
+
+			app := tc.order.AppendU128([]byte{0xaa}, tc.in)
+			tt.MustEqual("aa"+tc.out, hex.EncodeToString(app))
+
+			var v U128
+			var err error
+			if tc.order == BigEndian {
+				v, err = U128FromBigEndian(b)
+			} else {
+				v, err = U128FromLittleEndian(b)
+			}
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestByteOrderI128(t *testing.T) {
+	for idx, tc := range []struct {
+		order ByteOrder
+		in    I128
+		out   string
+	}{
+		{BigEndian, i64(-1), "ffffffffffffffffffffffffffffffff"},
+		{BigEndian, i64(-2), "fffffffffffffffffffffffffffffffe"},
+		{BigEndian, MinI128, "80000000000000000000000000000000"},
+		{BigEndian, MaxI128, "7fffffffffffffffffffffffffffffff"},
+		{LittleEndian, i64(-2), "feffffffffffffffffffffffffffffff"},
+		{LittleEndian, MinI128, "00000000000000000000000000000080"},
+		{LittleEndian, i64(0x0102), "02010000000000000000000000000000"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%s", idx, tc.order, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+
+			b := make([]byte, 16)
+			tc.order.PutI128(b, tc.in)
+			tt.MustEqual(tc.out, hex.EncodeToString(b))
+			tt.MustEqual(tc.in, tc.order.I128(b))
+
+			app := tc.order.AppendI128([]byte{0xaa}, tc.in)
+			tt.MustEqual("aa"+tc.out, hex.EncodeToString(app))
+
+			var v I128
+			var err error
+			if tc.order == BigEndian {
+				v, err = I128FromBigEndian(b)
+			} else {
+				v, err = I128FromLittleEndian(b)
+			}
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestFromEndianShort(t *testing.T) {
+	tt := assert.WrapTB(t)
+	for _, b := range [][]byte{nil, {}, make([]byte, 15)} {
+		_, err := U128FromBigEndian(b)
+		tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+		_, err = U128FromLittleEndian(b)
+		tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+		_, err = I128FromBigEndian(b)
+		tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+		_, err = I128FromLittleEndian(b)
+		tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+	}
+}
+
+func TestAppendEndianReusesCapacity(t *testing.T) {
+	tt := assert.WrapTB(t)
+	buf := make([]byte, 0, 32)
+	buf = MaxU128.AppendBigEndian(buf)
+	buf = MinI128.AppendLittleEndian(buf)
+	tt.MustEqual(32, len(buf))
+	tt.MustEqual(32, cap(buf))
+	tt.MustEqual(MaxU128, MustU128FromBigEndian(buf))
+	tt.MustEqual(MinI128, MustI128FromLittleEndian(buf[16:]))
+}
+
+func TestByteOrderString(t *testing.T) {
+	tt := assert.WrapTB(t)
+	tt.MustEqual("BigEndian", BigEndian.String())
+	tt.MustEqual("LittleEndian", LittleEndian.String())
+	tt.MustEqual("num.LittleEndian", fmt.Sprintf("%#v", LittleEndian))
+}
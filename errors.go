@@ -0,0 +1,42 @@
+package num
+
+import (
+	"strconv"
+)
+
+var (
+	// ErrRange indicates that a value is out of range for the target type.
+	// It is the same value as strconv.ErrRange, so errors.Is will match
+	// either.
+	ErrRange = strconv.ErrRange
+
+	// ErrSyntax indicates that a value does not have the right syntax for the
+	// target type. It is the same value as strconv.ErrSyntax, so errors.Is
+	// will match either.
+	ErrSyntax = strconv.ErrSyntax
+)
+
+// NumError records a failed conversion. It mirrors strconv.NumError.
+//
+// NumError is returned by every function in this package that parses or
+// decodes a U128 or I128 (constructors, Scan, UnmarshalText, UnmarshalJSON,
+// etc), and is used as the panic value by the Must* constructors.
+type NumError struct {
+	Func string // the failing function (U128FromString, I128.UnmarshalJSON, ...)
+	Num  string // the input
+	Err  error  // the reason the conversion failed (ErrRange, ErrSyntax, etc.)
+}
+
+func (e *NumError) Error() string {
+	return "num: " + e.Func + ": parsing " + strconv.Quote(e.Num) + ": " + e.Err.Error()
+}
+
+func (e *NumError) Unwrap() error { return e.Err }
+
+func syntaxError(fn, str string) *NumError {
+	return &NumError{Func: fn, Num: str, Err: ErrSyntax}
+}
+
+func rangeError(fn, str string) *NumError {
+	return &NumError{Func: fn, Num: str, Err: ErrRange}
+}
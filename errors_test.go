@@ -0,0 +1,89 @@
+package num
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestNumErrorParse(t *testing.T) {
+	for idx, tc := range []struct {
+		fn   string
+		in   string
+		err  error
+		call func(s string) error
+	}{
+		{"U128FromString", "foo", ErrSyntax, func(s string) error { _, _, err := U128FromString(s); return err }},
+		{"U128FromString", "", ErrSyntax, func(s string) error { _, _, err := U128FromString(s); return err }},
+		{"I128FromString", "1.5", ErrSyntax, func(s string) error { _, _, err := I128FromString(s); return err }},
+		{"U128.UnmarshalText", "0xFF", ErrSyntax, func(s string) error { var u U128; return u.UnmarshalText([]byte(s)) }},
+		{"I128.UnmarshalText", "x", ErrSyntax, func(s string) error { var i I128; return i.UnmarshalText([]byte(s)) }},
+		{"U128.UnmarshalJSON", `"1`, ErrSyntax, func(s string) error { var u U128; return u.UnmarshalJSON([]byte(s)) }},
+		{"I128.UnmarshalJSON", `"x"`, ErrSyntax, func(s string) error { var i I128; return i.UnmarshalJSON([]byte(s)) }},
+		{"U128.Scan", "-1", ErrRange, func(s string) error { var u U128; _, err := fmt.Sscan(s, &u); return err }},
+		{"I128.Scan", "170141183460469231731687303715884105728", ErrRange, func(s string) error { var i I128; _, err := fmt.Sscan(s, &i); return err }},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%s", idx, tc.fn, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			err := tc.call(tc.in)
+
+			var nerr *NumError
+			tt.MustAssert(errors.As(err, &nerr), "%T %v", err, err)
+			tt.MustEqual(tc.fn, nerr.Func)
+			tt.MustAssert(errors.Is(err, tc.err))
+		})
+	}
+}
+
+func TestNumErrorMatchesStrconv(t *testing.T) {
+	tt := assert.WrapTB(t)
+	_, _, err := U128FromString("nope")
+	tt.MustAssert(errors.Is(err, strconv.ErrSyntax))
+	tt.MustEqual(`num: U128FromString: parsing "nope": invalid syntax`, err.Error())
+}
+
+func TestNumErrorJSON(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var v struct{ N U128 }
+	err := json.Unmarshal([]byte(`{"N":"nope"}`), &v)
+	var nerr *NumError
+	tt.MustAssert(errors.As(err, &nerr))
+	tt.MustEqual("U128.UnmarshalJSON", nerr.Func)
+}
+
+func TestNumErrorMustPanics(t *testing.T) {
+	for idx, tc := range []struct {
+		fn   string
+		err  error
+		call func()
+	}{
+		{"MustU128FromI64", ErrRange, func() { MustU128FromI64(-1) }},
+		{"MustU128FromString", ErrSyntax, func() { MustU128FromString("x") }},
+		{"MustU128FromString", ErrRange, func() { MustU128FromString("-1") }},
+		{"MustU128FromBigInt", ErrRange, func() { MustU128FromBigInt(big.NewInt(-1)) }},
+		{"MustU128FromFloat32", ErrRange, func() { MustU128FromFloat32(-1) }},
+		{"MustU128FromFloat64", ErrRange, func() { MustU128FromFloat64(math.NaN()) }},
+		{"MustI128FromString", ErrSyntax, func() { MustI128FromString("x") }},
+		{"MustI128FromString", ErrRange, func() { MustI128FromString("170141183460469231731687303715884105728") }},
+		{"MustI128FromBigInt", ErrRange, func() { MustI128FromBigInt(maxBigU128) }},
+		{"MustI128FromFloat32", ErrRange, func() { MustI128FromFloat32(float32(math.Inf(1))) }},
+		{"MustI128FromFloat64", ErrRange, func() { MustI128FromFloat64(math.Inf(-1)) }},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.fn), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			defer func() {
+				err, ok := recover().(*NumError)
+				tt.MustAssert(ok)
+				tt.MustEqual(tc.fn, err.Func)
+				tt.MustAssert(errors.Is(err, tc.err))
+			}()
+			tc.call()
+		})
+	}
+}
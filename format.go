@@ -0,0 +1,356 @@
+// This file contains a modified version of the float formatting routines
+// from strconv/ftoa.go and fmt/format.go, adapted to format the exact decimal
+// digits of a 128-bit integer.
+//
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package num
+
+import (
+	"fmt"
+)
+
+// decimalDigits holds the decimal digits of an integer's magnitude, without
+// leading or trailing zeros. The value is 0.d[0:nd] * 10^dp.
+type decimalDigits struct {
+	d  []byte
+	nd int
+	dp int
+}
+
+func newDecimalDigits(u U128) decimalDigits {
+	if u.IsZero() {
+		return decimalDigits{}
+	}
+	d := []byte(u.String())
+	dp := len(d)
+	for len(d) > 0 && d[len(d)-1] == '0' {
+		d = d[:len(d)-1]
+	}
+	return decimalDigits{d: d, nd: len(d), dp: dp}
+}
+
+// shouldRoundUp reports whether the digits should be rounded up (as opposed
+// to down) when truncated to nd digits. Ties are rounded to even, as they
+// are in strconv.
+func (a *decimalDigits) shouldRoundUp(nd int) bool {
+	if a.d[nd] == '5' && nd+1 == a.nd { // exactly halfway - round to even
+		return nd > 0 && (a.d[nd-1]-'0')%2 == 1
+	}
+	return a.d[nd] >= '5'
+}
+
+// round rounds a to nd digits (or fewer).
+func (a *decimalDigits) round(nd int) {
+	if nd < 0 || nd >= a.nd {
+		return
+	}
+	if a.shouldRoundUp(nd) {
+		a.roundUp(nd)
+	} else {
+		a.roundDown(nd)
+	}
+}
+
+func (a *decimalDigits) roundDown(nd int) {
+	a.nd = nd
+	a.trim()
+}
+
+func (a *decimalDigits) roundUp(nd int) {
+	// round up
+	for i := nd - 1; i >= 0; i-- {
+		c := a.d[i]
+		if c < '9' { // can stop after this digit
+			a.d[i]++
+			a.nd = i + 1
+			return
+		}
+	}
+
+	// Number is all 9s. Change to single 1 with adjusted decimal point.
+	a.d[0] = '1'
+	a.nd = 1
+	a.dp++
+}
+
+func (a *decimalDigits) trim() {
+	for a.nd > 0 && a.d[a.nd-1] == '0' {
+		a.nd--
+	}
+	if a.nd == 0 {
+		a.dp = 0
+	}
+}
+
+// appendFloat formats the magnitude u (negated if neg is set) as if it
+// were a float, following the rules of strconv.AppendFloat for the 'e', 'E',
+// 'f', 'F', 'g' and 'G' formats. A negative prec means "the smallest number
+// of digits necessary to represent the value exactly".
+func appendFloat(dst []byte, neg bool, u U128, fmtc byte, prec int) []byte {
+	digs := newDecimalDigits(u)
+	shortest := prec < 0
+
+	if shortest {
+		switch fmtc {
+		case 'e', 'E':
+			prec = max(digs.nd-1, 0)
+		case 'f', 'F':
+			prec = 0
+		case 'g', 'G':
+			prec = digs.nd
+		}
+	} else {
+		switch fmtc {
+		case 'e', 'E':
+			digs.round(prec + 1)
+		case 'f', 'F':
+			digs.round(digs.dp + prec)
+		case 'g', 'G':
+			if prec == 0 {
+				prec = 1
+			}
+			digs.round(prec)
+		}
+	}
+
+	switch fmtc {
+	case 'e', 'E':
+		return fmtE(dst, neg, digs, prec, fmtc)
+	case 'f', 'F':
+		return fmtF(dst, neg, digs, prec)
+	case 'g', 'G':
+		eprec := prec
+		if eprec > digs.nd && digs.nd >= digs.dp {
+			eprec = digs.nd
+		}
+		// %e is used if the exponent from the conversion
+		// is less than -4 or greater than or equal to the precision.
+		// if precision was the shortest possible, use precision 6 for this decision.
+		if shortest {
+			eprec = 6
+		}
+		exp := digs.dp - 1
+		if exp < -4 || exp >= eprec {
+			if prec > digs.nd {
+				prec = digs.nd
+			}
+			return fmtE(dst, neg, digs, max(prec-1, 0), fmtc+'e'-'g')
+		}
+		if prec > digs.dp {
+			prec = digs.nd
+		}
+		return fmtF(dst, neg, digs, max(prec-digs.dp, 0))
+	}
+
+	panic("num: unknown float format")
+}
+
+// %e: -d.ddddde±dd
+func fmtE(dst []byte, neg bool, d decimalDigits, prec int, fmtc byte) []byte {
+	// sign
+	if neg {
+		dst = append(dst, '-')
+	}
+
+	// first digit
+	ch := byte('0')
+	if d.nd != 0 {
+		ch = d.d[0]
+	}
+	dst = append(dst, ch)
+
+	// .moredigits
+	if prec > 0 {
+		dst = append(dst, '.')
+		i := 1
+		m := min(d.nd, prec+1)
+		if i < m {
+			dst = append(dst, d.d[i:m]...)
+			i = m
+		}
+		for ; i <= prec; i++ {
+			dst = append(dst, '0')
+		}
+	}
+
+	// e±
+	dst = append(dst, fmtc)
+	exp := d.dp - 1
+	if d.nd == 0 { // special case: 0 has exponent 0
+		exp = 0
+	}
+	dst = append(dst, '+') // Integers never have a negative exponent.
+
+	// dd or ddd
+	switch {
+	case exp < 10:
+		dst = append(dst, '0', byte(exp)+'0')
+	case exp < 100:
+		dst = append(dst, byte(exp/10)+'0', byte(exp%10)+'0')
+	default:
+		dst = append(dst, byte(exp/100)+'0', byte(exp/10)%10+'0', byte(exp%10)+'0')
+	}
+
+	return dst
+}
+
+// %f: -ddddddd.ddddd
+func fmtF(dst []byte, neg bool, d decimalDigits, prec int) []byte {
+	// sign
+	if neg {
+		dst = append(dst, '-')
+	}
+
+	// integer, padded with zeros as needed.
+	if d.dp > 0 {
+		m := min(d.nd, d.dp)
+		dst = append(dst, d.d[:m]...)
+		for ; m < d.dp; m++ {
+			dst = append(dst, '0')
+		}
+	} else {
+		dst = append(dst, '0')
+	}
+
+	// fraction
+	if prec > 0 {
+		dst = append(dst, '.')
+		for i := 1; i <= prec; i++ {
+			ch := byte('0')
+			if j := d.dp + i - 1; 0 <= j && j < d.nd {
+				ch = d.d[j]
+			}
+			dst = append(dst, ch)
+		}
+	}
+
+	return dst
+}
+
+// formatFloat implements the 'e', 'E', 'f', 'F', 'g' and 'G' verbs for
+// fmt.Formatter, honouring the same flags, width and precision as fmt does
+// for a float64.
+func formatFloat(s fmt.State, verb rune, neg bool, u U128) {
+	prec, hasPrec := s.Precision()
+	if !hasPrec {
+		prec = -1
+		if verb == 'e' || verb == 'E' || verb == 'f' || verb == 'F' {
+			prec = 6
+		}
+	}
+
+	// Format number, reserving space for leading + sign if needed.
+	num := appendFloat(make([]byte, 1, 64), neg, u, byte(verb), prec)
+	if num[1] == '-' {
+		num = num[1:]
+	} else {
+		num[0] = '+'
+	}
+
+	// If we're using the space flag, replace the + sign with a space.
+	if s.Flag(' ') && num[0] == '+' && !s.Flag('+') {
+		num[0] = ' '
+	}
+
+	// The sharp flag forces printing a decimal point but removes
+	// trailing zeros for %e, %f with the sharp flag. For %g, trailing
+	// zeros are kept up to the precision.
+	if s.Flag('#') {
+		digits := 0
+		if verb == 'g' || verb == 'G' {
+			digits = prec
+			// If no precision is set explicitly use a precision of 6.
+			if digits == -1 {
+				digits = 6
+			}
+		}
+
+		var tail []byte
+		hasDecimalPoint := false
+		sawNonzeroDigit := false
+		// Starting from i = 1 to skip sign at num[0].
+		for i := 1; i < len(num); i++ {
+			switch num[i] {
+			case '.':
+				hasDecimalPoint = true
+			case 'e', 'E':
+				tail = append(tail, num[i:]...)
+				num = num[:i]
+			default:
+				if num[i] != '0' {
+					sawNonzeroDigit = true
+				}
+				// Count significant digits after the first non-zero digit.
+				if sawNonzeroDigit {
+					digits--
+				}
+			}
+		}
+		if !hasDecimalPoint {
+			// Leading digit 0 should contribute once to digits.
+			if len(num) == 2 && num[1] == '0' {
+				digits--
+			}
+			num = append(num, '.')
+		}
+		for digits > 0 {
+			num = append(num, '0')
+			digits--
+		}
+		num = append(num, tail...)
+	}
+
+	// We want a sign if asked for and if the sign is not positive.
+	if !(s.Flag('+') || num[0] != '+') {
+		// No sign to show and the number is positive; just print the unsigned number.
+		num = num[1:]
+	} else if width, ok := s.Width(); ok && s.Flag('0') && width > len(num) {
+		// If we're zero padding to the left we want the sign before the leading zeros.
+		// Achieve this by writing the sign out and then padding the unsigned number.
+		s.Write(num[:1])
+		writePadding(s, width-len(num), '0')
+		s.Write(num[1:])
+		return
+	}
+
+	width, _ := s.Width()
+	if s.Flag('-') {
+		s.Write(num)
+		writePadding(s, width-len(num), ' ')
+	} else {
+		pad := byte(' ')
+		if s.Flag('0') {
+			pad = '0'
+		}
+		writePadding(s, width-len(num), pad)
+		s.Write(num)
+	}
+}
+
+func writePadding(s fmt.State, n int, pad byte) {
+	if n <= 0 {
+		return
+	}
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = pad
+	}
+	s.Write(buf)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,85 @@
+package num
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+var floatFormats = []string{
+	"%e", "%E", "%f", "%F", "%g", "%G",
+	"%.0e", "%.1e", "%.3e", "%.20e",
+	"%.0f", "%.2f",
+	"%.0g", "%.1g", "%.3g", "%.10g", "%.20g",
+	"%+e", "% e", "%+.2f", "% .2f", "%+g",
+	"%12.3e", "%-12.3e|", "%012.3e", "%+012.3e", "%025f", "%-25f|",
+	"%#e", "%#.0e", "%#.0f", "%#g", "%#.3g", "%#.10g",
+}
+
+func TestFormatFloatMatchesFloat64(t *testing.T) {
+	// All integers below 1<<53 are exactly representable as a float64, so
+	// the float formatter is a perfect oracle for them.
+	rng := rand.New(rand.NewSource(globalRNG.Int63()))
+
+	var vals = []int64{0, 1, 5, 9, 10, 15, 25, 35, 99, 100, 125, 250, 251, 999, 9999, 99999, 1000000, 9999995, 123456789, 1 << 52}
+	for i := 0; i < 500; i++ {
+		vals = append(vals, rng.Int63n(1<<53))
+	}
+
+	for _, f := range floatFormats {
+		t.Run(f, func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			for _, v := range vals {
+				tt.MustEqual(fmt.Sprintf(f, float64(v)), fmt.Sprintf(f, u64(uint64(v))), "%d", v)
+				tt.MustEqual(fmt.Sprintf(f, float64(v)), fmt.Sprintf(f, i64(v)), "%d", v)
+				tt.MustEqual(fmt.Sprintf(f, float64(-v)), fmt.Sprintf(f, i64(-v)), "%d", -v)
+			}
+		})
+	}
+}
+
+func TestFormatFloat(t *testing.T) {
+	for idx, tc := range []struct {
+		v   interface{}
+		fmt string
+		out string
+	}{
+		{MaxU128, "%.4e", "3.4028e+38"},
+		{MaxU128, "%e", "3.402824e+38"},
+		{MaxU128, "%E", "3.402824E+38"},
+		{MaxU128, "%.38e", "3.40282366920938463463374607431768211455e+38"},
+		{MaxU128, "%.40e", "3.4028236692093846346337460743176821145500e+38"},
+		{MaxU128, "%g", "3.40282366920938463463374607431768211455e+38"},
+		{MaxU128, "%.5g", "3.4028e+38"},
+		{MaxU128, "%f", "340282366920938463463374607431768211455.000000"},
+		{MaxU128, "%.0f", "340282366920938463463374607431768211455"},
+		{MaxI128, "%.3e", "1.701e+38"},
+		{MinI128, "%.3e", "-1.701e+38"},
+		{MinI128, "%g", "-1.70141183460469231731687303715884105728e+38"},
+
+		// float64 would round these incorrectly as they can't be represented exactly:
+		{u128s("12345678901234567895"), "%.18e", "1.234567890123456790e+19"},
+		{u128s("12345678901234567885"), "%.18e", "1.234567890123456788e+19"},
+		{u128s("12345678901234567885000"), "%.18e", "1.234567890123456788e+22"},
+		{u128s("12345678901234567885001"), "%.18e", "1.234567890123456789e+22"},
+		{u128s("99999999999999999999999"), "%.3e", "1.000e+23"},
+		{i128s("-99999999999999999999999"), "%.3g", "-1e+23"},
+
+		{U128Hex(u64(1500)), "%.1e", "1.5e+03"},
+		{I128Number(i64(-1500)), "%+08.0f", "-0001500"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%v", idx, tc.fmt, tc.v), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, fmt.Sprintf(tc.fmt, tc.v))
+		})
+	}
+}
+
+func TestFormatIntegerVerbsUnchanged(t *testing.T) {
+	tt := assert.WrapTB(t)
+	tt.MustEqual("1234", fmt.Sprintf("%v", u64(1234)))
+	tt.MustEqual("-1234", fmt.Sprintf("%d", i64(-1234)))
+	tt.MustEqual("4d2", fmt.Sprintf("%x", u64(1234)))
+}
@@ -397,7 +397,8 @@ func TestFuzz(t *testing.T) {
 				if err != nil {
 					failures[implIdx][opIdx]++
 					failCount++
-					t.Logf("impl %s: %s\n%s\n\n", fuzzImpl.Name(), op.Print(source.Operands()...), err)
+					t.Logf("impl %s: %s\n%s\n%s\n\n", fuzzImpl.Name(), op.Print(source.Operands()...), err,
+						fuzzGoOperands(fuzzImpl.Name(), source.Operands()))
 				}
 			}
 		}
@@ -435,6 +436,25 @@ func TestFuzz(t *testing.T) {
 	}
 }
 
+// fuzzGoOperands formats the operands of a failed op as Go expressions, so
+// they can be pasted straight into a regression test.
+func fuzzGoOperands(impl string, operands []*big.Int) string {
+	out := make([]string, len(operands))
+	for i, o := range operands {
+		if impl == string(fuzzTypeU128) {
+			if u, inRange := U128FromBigInt(o); inRange {
+				out[i] = u.GoString()
+				continue
+			}
+		} else if v, inRange := I128FromBigInt(o); inRange {
+			out[i] = v.GoString()
+			continue
+		}
+		out[i] = o.String()
+	}
+	return "operands: " + strings.Join(out, ", ")
+}
+
 func (op fuzzOp) Print(operands ...*big.Int) string {
 	// NEWOP: please add a human-readale format for your op here; this is used
 	// for reporting errors and should show the operation, i.e. "2 + 2".
@@ -0,0 +1,179 @@
+package num
+
+import (
+	"strconv"
+)
+
+var (
+	siSuffixes  = []string{"", "k", "M", "G", "T", "P", "E", "Z", "Y", "R", "Q"}
+	iecSuffixes = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi", "Ri", "Qi"}
+)
+
+// FormatGrouped formats u in decimal, inserting sep between each group of
+// size digits, counting from the right:
+//
+//	u := U128From64(1234567)
+//	u.FormatGrouped(",", 3) // 1,234,567
+//	u.FormatGrouped(" ", 4) // 123 4567
+//
+// FormatGrouped panics if size < 1.
+func (u U128) FormatGrouped(sep string, size int) string {
+	return string(appendGrouped(nil, false, u, sep, size))
+}
+
+// FormatSI formats u scaled down by the largest SI prefix (k, M, G, T, P, E,
+// Z, Y, R, Q) that leaves at least 1 before the decimal point, with prec
+// digits after the decimal point, followed by the prefix symbol:
+//
+//	U128From64(1234567).FormatSI(2) // 1.23M
+//
+// Values less than 1000 are formatted as integers without a fractional part
+// or suffix. Rounding is exact; ties are rounded to even. FormatSI panics if
+// prec < 0.
+func (u U128) FormatSI(prec int) string {
+	return string(appendSI(nil, false, u, prec))
+}
+
+// FormatIEC formats u scaled down by the largest IEC binary prefix (Ki, Mi,
+// Gi, Ti, Pi, Ei, Zi, Yi, Ri, Qi) that leaves at least 1 before the decimal
+// point, with prec digits after the decimal point, followed by the prefix
+// symbol:
+//
+//	U128From64(1 << 50).FormatIEC(1) // 1.0Pi
+//
+// Ri (1<<90) and Qi (1<<100) have not been standardised by the IEC, but are
+// in common use alongside the SI R and Q prefixes.
+//
+// Values less than 1024 are formatted as integers without a fractional part
+// or suffix. Rounding is exact; ties are rounded to even. FormatIEC panics if
+// prec < 0.
+func (u U128) FormatIEC(prec int) string {
+	return string(appendIEC(nil, false, u, prec))
+}
+
+// FormatGrouped formats i in decimal, inserting sep between each group of
+// size digits. See U128.FormatGrouped.
+func (i I128) FormatGrouped(sep string, size int) string {
+	return string(appendGrouped(nil, i.hi&signBit != 0, i.AbsU128(), sep, size))
+}
+
+// FormatSI formats i scaled by an SI prefix. See U128.FormatSI.
+func (i I128) FormatSI(prec int) string {
+	return string(appendSI(nil, i.hi&signBit != 0, i.AbsU128(), prec))
+}
+
+// FormatIEC formats i scaled by an IEC binary prefix. See U128.FormatIEC.
+func (i I128) FormatIEC(prec int) string {
+	return string(appendIEC(nil, i.hi&signBit != 0, i.AbsU128(), prec))
+}
+
+func appendGrouped(dst []byte, neg bool, u U128, sep string, size int) []byte {
+	if size < 1 {
+		panic("num: group size must be > 0")
+	}
+	if neg {
+		dst = append(dst, '-')
+	}
+	s := u.String()
+	first := len(s) % size
+	if first == 0 {
+		first = size
+	}
+	dst = append(dst, s[:first]...)
+	for i := first; i < len(s); i += size {
+		dst = append(dst, sep...)
+		dst = append(dst, s[i:i+size]...)
+	}
+	return dst
+}
+
+func appendSI(dst []byte, neg bool, u U128, prec int) []byte {
+	if prec < 0 {
+		panic("num: precision must be >= 0")
+	}
+	if u.LessThan64(1000) {
+		if neg && u.lo != 0 {
+			dst = append(dst, '-')
+		}
+		return strconv.AppendUint(dst, u.lo, 10)
+	}
+
+	digs := newDecimalDigits(u)
+	k := min((digs.dp-1)/3, len(siSuffixes)-1)
+	for {
+		scaled := digs
+		scaled.d = append([]byte(nil), digs.d...)
+		scaled.dp -= 3 * k
+		scaled.round(scaled.dp + prec)
+
+		// Rounding may carry into the next prefix, i.e. 999.95k -> 1000.0k,
+		// which should be 1.0M:
+		if scaled.dp > 3 && k < len(siSuffixes)-1 {
+			k++
+			continue
+		}
+		dst = fmtF(dst, neg, scaled, prec)
+		return append(dst, siSuffixes[k]...)
+	}
+}
+
+func appendIEC(dst []byte, neg bool, u U128, prec int) []byte {
+	if prec < 0 {
+		panic("num: precision must be >= 0")
+	}
+	if u.LessThan64(1024) {
+		if neg && u.lo != 0 {
+			dst = append(dst, '-')
+		}
+		return strconv.AppendUint(dst, u.lo, 10)
+	}
+
+	k := min((u.BitLen()-1)/10, len(iecSuffixes)-1)
+	frac := make([]byte, prec)
+	for {
+		shift := uint(10 * k)
+		mask := U128From64(1).Lsh(shift).Dec()
+		q, r := u.Rsh(shift), u.And(mask)
+
+		// r < 1<<100, so multiplying by 10 can't overflow:
+		for i := range frac {
+			r = r.Mul64(10)
+			frac[i] = byte(r.Rsh(shift).lo) + '0'
+			r = r.And(mask)
+		}
+
+		half := U128From64(1).Lsh(shift - 1)
+		odd := q.lo&1 == 1
+		if prec > 0 {
+			odd = (frac[prec-1]-'0')%2 == 1
+		}
+		if cmp := r.Cmp(half); cmp > 0 || (cmp == 0 && odd) {
+			i := prec - 1
+			for ; i >= 0 && frac[i] == '9'; i-- {
+				frac[i] = '0'
+			}
+			if i >= 0 {
+				frac[i]++
+			} else {
+				q = q.Inc()
+			}
+		}
+
+		// Rounding may carry into the next prefix, i.e. 1023.95Ki -> 1024.0Ki,
+		// which should be 1.0Mi:
+		if q.GreaterOrEqualTo64(1024) && k < len(iecSuffixes)-1 {
+			k++
+			continue
+		}
+
+		if neg {
+			dst = append(dst, '-')
+		}
+		dst = append(dst, q.String()...)
+		if prec > 0 {
+			dst = append(dst, '.')
+			dst = append(dst, frac...)
+		}
+		return append(dst, iecSuffixes[k]...)
+	}
+}
@@ -0,0 +1,111 @@
+package num
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestFormatGrouped(t *testing.T) {
+	for idx, tc := range []struct {
+		v    interface{ FormatGrouped(string, int) string }
+		sep  string
+		size int
+		out  string
+	}{
+		{u64(0), ",", 3, "0"},
+		{u64(999), ",", 3, "999"},
+		{u64(1000), ",", 3, "1,000"},
+		{u64(1234567), ",", 3, "1,234,567"},
+		{u64(123456), ",", 3, "123,456"},
+		{u64(1234567), " ", 4, "123 4567"},
+		{u64(1234567), "'", 1, "1'2'3'4'5'6'7"},
+		{u64(1234567), "", 3, "1234567"},
+		{MaxU128, ",", 3, "340,282,366,920,938,463,463,374,607,431,768,211,455"},
+		{MaxU128, " ", 3, "340 282 366 920 938 463 463 374 607 431 768 211 455"},
+		{i64(-1), ",", 3, "-1"},
+		{i64(-1000), ",", 3, "-1,000"},
+		{i64(-123456), ".", 3, "-123.456"},
+		{MinI128, ",", 3, "-170,141,183,460,469,231,731,687,303,715,884,105,728"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.out), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, tc.v.FormatGrouped(tc.sep, tc.size))
+		})
+	}
+}
+
+func TestFormatGroupedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal()
+		}
+	}()
+	u64(1).FormatGrouped(",", 0)
+}
+
+func TestFormatSI(t *testing.T) {
+	for idx, tc := range []struct {
+		v    interface{ FormatSI(int) string }
+		prec int
+		out  string
+	}{
+		{u64(0), 2, "0"},
+		{u64(999), 2, "999"},
+		{u64(1000), 2, "1.00k"},
+		{u64(1000), 0, "1k"},
+		{u64(1234567), 2, "1.23M"},
+		{u64(1235000), 2, "1.24M"}, // tie, round to even
+		{u64(1245000), 2, "1.24M"}, // tie, round to even
+		{u64(1245001), 2, "1.25M"},
+		{u64(999499), 0, "999k"},
+		{u64(999500), 0, "1M"},
+		{u64(999950), 1, "1.0M"},
+		{u64(999949), 1, "999.9k"},
+		{u128s("1000000000000000000"), 1, "1.0E"},
+		{u128s("1500000000000000000000000000"), 1, "1.5R"},
+		{u128s("1000000000000000000000000000000"), 3, "1.000Q"},
+		{MaxU128, 2, "340282366.92Q"},
+		{MaxU128, 0, "340282367Q"},
+		{i64(-999), 1, "-999"},
+		{i64(-1500), 1, "-1.5k"},
+		{MinI128, 1, "-170141183.5Q"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.out), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, tc.v.FormatSI(tc.prec))
+		})
+	}
+}
+
+func TestFormatIEC(t *testing.T) {
+	for idx, tc := range []struct {
+		v    interface{ FormatIEC(int) string }
+		prec int
+		out  string
+	}{
+		{u64(0), 2, "0"},
+		{u64(1023), 2, "1023"},
+		{u64(1024), 2, "1.00Ki"},
+		{u64(1536), 1, "1.5Ki"},
+		{u64(1536), 0, "2Ki"}, // tie, round to even
+		{u64(2560), 0, "2Ki"}, // tie, round to even
+		{u64(2561), 0, "3Ki"},
+		{u64(1 << 50), 1, "1.0Pi"},
+		{u64(1<<20 - 1), 2, "1.00Mi"}, // 1023.999Ki rounds up into the next prefix
+		{u64(1<<20 - 1), 3, "1023.999Ki"},
+		{u64(1<<20 - 1), 4, "1023.9990Ki"},
+		{u128s("0x400000000000000000000000"), 0, "16Ri"},     // 1<<94
+		{u128s("0x10000000000000000000000000"), 2, "1.00Qi"}, // 1<<100
+		{MaxU128, 2, "268435456.00Qi"},
+		{MaxU128, 0, "268435456Qi"},
+		{i64(-1536), 1, "-1.5Ki"},
+		{MinI128, 0, "-134217728Qi"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.out), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, tc.v.FormatIEC(tc.prec))
+		})
+	}
+}
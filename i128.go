@@ -2,8 +2,10 @@ package num
 
 import (
 	"fmt"
+	"io"
 	"math/big"
 	"math/bits"
+	"strconv"
 )
 
 const (
@@ -46,24 +48,34 @@ func I128FromU64(v uint64) I128 { return I128{lo: v} }
 // I128FromString creates a I128 from a string. Overflow truncates to
 // MaxI128/MinI128 and sets accurate to 'false'. Only decimal strings are
 // currently supported.
+//
+// If s is not a valid decimal string, err is a *NumError wrapping ErrSyntax.
 func I128FromString(s string) (out I128, accurate bool, err error) {
+	out, accurate, ok := i128FromString(s)
+	if !ok {
+		return out, false, syntaxError("I128FromString", s)
+	}
+	return out, accurate, nil
+}
+
+func i128FromString(s string) (out I128, accurate bool, ok bool) {
 	// This deliberately limits the scope of what we accept as input just in case
 	// we decide to hand-roll our own fast decimal-only parser:
 	b, ok := new(big.Int).SetString(s, 10)
 	if !ok {
-		return out, false, fmt.Errorf("num: i128 string %q invalid", s)
+		return out, false, false
 	}
 	out, accurate = I128FromBigInt(b)
-	return out, accurate, nil
+	return out, accurate, true
 }
 
 func MustI128FromString(s string) I128 {
-	out, inRange, err := I128FromString(s)
-	if err != nil {
-		panic(err)
+	out, inRange, ok := i128FromString(s)
+	if !ok {
+		panic(syntaxError("MustI128FromString", s))
 	}
 	if !inRange {
-		panic(fmt.Errorf("num: string %q was not in valid I128 range", s))
+		panic(rangeError("MustI128FromString", s))
 	}
 	return out
 }
@@ -142,7 +154,7 @@ func I128FromBigInt(v *big.Int) (out I128, accurate bool) {
 func MustI128FromBigInt(b *big.Int) I128 {
 	out, inRange := I128FromBigInt(b)
 	if !inRange {
-		panic(fmt.Errorf("num: big.Int %d was not in valid I128 range", b))
+		panic(rangeError("MustI128FromBigInt", b.String()))
 	}
 	return out
 }
@@ -154,7 +166,7 @@ func I128FromFloat32(f float32) (out I128, inRange bool) {
 func MustI128FromFloat32(f float32) I128 {
 	out, inRange := I128FromFloat32(f)
 	if !inRange {
-		panic(fmt.Errorf("num: float32 %f was not in valid I128 range", f))
+		panic(rangeError("MustI128FromFloat32", strconv.FormatFloat(float64(f), 'g', -1, 32)))
 	}
 	return out
 }
@@ -204,7 +216,7 @@ func I128FromFloat64(f float64) (out I128, inRange bool) {
 func MustI128FromFloat64(f float64) I128 {
 	out, inRange := I128FromFloat64(f)
 	if !inRange {
-		panic(fmt.Errorf("num: float64 %f was not in valid I128 range", f))
+		panic(rangeError("MustI128FromFloat64", strconv.FormatFloat(f, 'g', -1, 64)))
 	}
 	return out
 }
@@ -227,27 +239,43 @@ func (i I128) String() string {
 	return v.String()
 }
 
+// Scan implements fmt.Scanner. It supports the verbs %b, %o, %d, %x, %X
+// and %v, following the same rules as fmt does when scanning an int: a sign
+// is accepted, and for %v the base is taken from a "0b", "0o" or "0x" prefix
+// and underscores may separate digits. Unlike fmt, a leading "0" on its own
+// does not select octal, so "0123" scans as 123.
+//
+// Values outside the range of a I128 are rejected with ErrRange; see
+// I128Clamped if you would prefer to clamp them.
 func (i *I128) Scan(state fmt.ScanState, verb rune) error {
-	t, err := state.Token(true, nil)
-	if err != nil {
-		return err
-	}
-	ts := string(t)
+	return i.scan("I128.Scan", state, verb, false)
+}
 
-	v, inRange, err := I128FromString(ts)
-	if err != nil {
-		return err
-	} else if !inRange {
-		return fmt.Errorf("num: i128 value %q is not in range", ts)
+// GoString implements fmt.GoStringer, and is used for the %#v verb. The
+// result is a valid Go expression that creates i, i.e.
+// "num.I128From64(-1)" or "num.I128FromRaw(0x1, 0x0)".
+func (i I128) GoString() string {
+	if i.IsInt64() {
+		return "num.I128From64(" + strconv.FormatInt(i.AsInt64(), 10) + ")"
 	}
-	*i = v
-
-	return nil
+	return "num.I128FromRaw(0x" + strconv.FormatUint(i.hi, 16) + ", 0x" + strconv.FormatUint(i.lo, 16) + ")"
 }
 
+// Format implements fmt.Formatter. See U128.Format for the supported verbs.
 func (i I128) Format(s fmt.State, c rune) {
-	// FIXME: This is good enough for now, but not forever.
-	i.AsBigInt().Format(s, c)
+	switch c {
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		formatFloat(s, c, i.hi&signBit != 0, i.AbsU128())
+	case 'v':
+		if s.Flag('#') {
+			io.WriteString(s, i.GoString())
+			return
+		}
+		fallthrough
+	default:
+		// FIXME: This is good enough for now, but not forever.
+		i.AsBigInt().Format(s, c)
+	}
 }
 
 // IntoBigInt copies this I128 into a big.Int, allowing you to retain and
@@ -836,10 +864,19 @@ func (i I128) MarshalText() ([]byte, error) {
 	return []byte(i.String()), nil
 }
 
+// UnmarshalText implements encoding.TextUnmarshaler. Values outside the range
+// of a I128 are rejected with ErrRange; see I128Clamped if you would prefer to
+// clamp them.
 func (i *I128) UnmarshalText(bts []byte) (err error) {
-	v, _, err := I128FromString(string(bts))
-	if err != nil {
-		return err
+	return i.unmarshalText("I128.UnmarshalText", bts, false)
+}
+
+func (i *I128) unmarshalText(fn string, bts []byte, clamp bool) (err error) {
+	v, inRange, ok := i128FromString(string(bts))
+	if !ok {
+		return syntaxError(fn, string(bts))
+	} else if !inRange && !clamp {
+		return rangeError(fn, string(bts))
 	}
 	*i = v
 	return nil
@@ -849,19 +886,39 @@ func (i I128) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + i.String() + `"`), nil
 }
 
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON number or
+// string containing a decimal integer, a "0x"-prefixed hex integer, or a
+// decimal with a fraction or exponent if its value is an exact integer (i.e.
+// 1e20 or 1.5e1). JSON null leaves the value unchanged.
+//
+// Values outside the range of a I128 are rejected with ErrRange; see
+// I128Clamped if you would prefer to clamp them.
 func (i *I128) UnmarshalJSON(bts []byte) (err error) {
-	if bts[0] == '"' {
-		ln := len(bts)
-		if bts[ln-1] != '"' {
-			return fmt.Errorf("num: i128 invalid JSON %q", string(bts))
-		}
-		bts = bts[1 : ln-1]
-	}
+	return i.unmarshalJSON("I128.UnmarshalJSON", bts, false)
+}
 
-	v, _, err := I128FromString(string(bts))
-	if err != nil {
-		return err
-	}
-	*i = v
-	return nil
+// Put big-endian encoded two's complement bytes representing this I128 into
+// byte slice b. len(b) must be >= 16.
+func (i I128) PutBigEndian(b []byte) {
+	U128{hi: i.hi, lo: i.lo}.PutBigEndian(b)
+}
+
+// Decode 16 bytes as a big-endian two's complement I128. Panics if
+// len(b) < 16.
+func MustI128FromBigEndian(b []byte) I128 {
+	u := MustU128FromBigEndian(b)
+	return I128{hi: u.hi, lo: u.lo}
+}
+
+// Put little-endian encoded two's complement bytes representing this I128
+// into byte slice b. len(b) must be >= 16.
+func (i I128) PutLittleEndian(b []byte) {
+	U128{hi: i.hi, lo: i.lo}.PutLittleEndian(b)
+}
+
+// Decode 16 bytes as a little-endian two's complement I128. Panics if
+// len(b) < 16.
+func MustI128FromLittleEndian(b []byte) I128 {
+	u := MustU128FromLittleEndian(b)
+	return I128{hi: u.hi, lo: u.lo}
 }
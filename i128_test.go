@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+	"go/parser"
 	"math"
 	"math/big"
 	"math/rand"
@@ -302,6 +303,30 @@ func TestI128Format(t *testing.T) {
 	}
 }
 
+func TestI128GoString(t *testing.T) {
+	for idx, tc := range []struct {
+		v   I128
+		out string
+	}{
+		{zeroI128, "num.I128From64(0)"},
+		{i64(-1), "num.I128From64(-1)"},
+		{i64(minInt64), "num.I128From64(-9223372036854775808)"},
+		{i64(maxInt64), "num.I128From64(9223372036854775807)"},
+		{I128FromU64(maxUint64), "num.I128FromRaw(0x0, 0xffffffffffffffff)"},
+		{i64(minInt64).Sub64(1), "num.I128FromRaw(0xffffffffffffffff, 0x7fffffffffffffff)"},
+		{MinI128, "num.I128FromRaw(0x8000000000000000, 0x0)"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.v), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, tc.v.GoString())
+			tt.MustEqual(tc.out, fmt.Sprintf("%#v", tc.v))
+
+			_, err := parser.ParseExpr(tc.out)
+			tt.MustOK(err)
+		})
+	}
+}
+
 func TestI128From64(t *testing.T) {
 	for idx, tc := range []struct {
 		in  int64
@@ -622,7 +647,7 @@ func TestI128Scan(t *testing.T) {
 		ok  bool
 	}{
 		{"1", i64(1), true},
-		{"0xFF", zeroI128, false},
+		{"0xFF", i64(0xFF), true},
 		{"-1", i64(-1), true},
 		{"170141183460469231731687303715884105728", zeroI128, false},
 		{"-170141183460469231731687303715884105729", zeroI128, false},
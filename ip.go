@@ -0,0 +1,155 @@
+package num
+
+import (
+	"net"
+	"strconv"
+)
+
+// U128FromIP converts a 16 byte IPv6 address to a big-endian U128. 4 byte
+// IPv4 addresses are converted to their IPv4-mapped IPv6 form
+// (::ffff:a.b.c.d) first, as net.IP.To16 does.
+//
+// If ip is not a valid IP address, err is a *NumError wrapping ErrSyntax.
+func U128FromIP(ip net.IP) (out U128, err error) {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return out, syntaxError("U128FromIP", ip.String())
+	}
+	return MustU128FromBigEndian(ip16), nil
+}
+
+// AsIP returns u as a 16 byte IPv6 address.
+func (u U128) AsIP() net.IP {
+	ip := make(net.IP, net.IPv6len)
+	u.PutBigEndian(ip)
+	return ip
+}
+
+// IPv6Mask returns a U128 with the most significant bits bits set, which is
+// the network mask of an IPv6 prefix of that length. IPv6Mask panics if bits
+// is not between 0 and 128.
+func IPv6Mask(bits int) U128 {
+	if bits < 0 || bits > 128 {
+		panic("num: IPv6 prefix length out of range")
+	}
+	if bits == 0 {
+		return zeroU128
+	}
+	return MaxU128.Lsh(uint(128 - bits))
+}
+
+// IPv6Offset returns addr plus off. If the result is below :: or above
+// ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff, inRange is false and the result
+// wraps around.
+func IPv6Offset(addr U128, off I128) (out U128, inRange bool) {
+	if off.Sign() >= 0 {
+		out = addr.Add(off.AsU128())
+		return out, !out.LessThan(addr)
+	}
+	mag := off.AbsU128()
+	return addr.Sub(mag), !mag.GreaterThan(addr)
+}
+
+// IPv6Distance returns the absolute difference between two addresses. The
+// number of addresses in the range [a, b], inclusive, is one more than this,
+// which overflows if a and b are the lowest and highest addresses.
+func IPv6Distance(a, b U128) U128 {
+	return DifferenceU128(a, b)
+}
+
+// IPv6Prefix is an IPv6 address with a prefix length, i.e. 2001:db8::/32.
+//
+// Addr is not required to have its host bits cleared; use Masked to do so.
+// Methods that use the prefix length panic if Bits is not between 0 and 128.
+type IPv6Prefix struct {
+	Addr U128
+	Bits int
+}
+
+// ParseIPv6Prefix parses s as an IPv6 prefix in CIDR notation, i.e.
+// "2001:db8::1/64". The address is kept as written, with its host bits
+// intact.
+//
+// If s is not a valid IPv6 prefix, err is a *NumError wrapping ErrSyntax.
+func ParseIPv6Prefix(s string) (p IPv6Prefix, err error) {
+	ip, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return p, syntaxError("ParseIPv6Prefix", s)
+	}
+	ones, bits := n.Mask.Size()
+	if bits != 128 {
+		return p, syntaxError("ParseIPv6Prefix", s)
+	}
+	return IPv6Prefix{Addr: MustU128FromBigEndian(ip.To16()), Bits: ones}, nil
+}
+
+// IPv6PrefixFromIPNet converts n to an IPv6Prefix. n must have a 128-bit
+// mask with contiguous leading ones; otherwise err is a *NumError wrapping
+// ErrSyntax.
+func IPv6PrefixFromIPNet(n *net.IPNet) (p IPv6Prefix, err error) {
+	ones, bits := n.Mask.Size()
+	ip := n.IP.To16()
+	if bits != 128 || ip == nil {
+		return p, syntaxError("IPv6PrefixFromIPNet", n.String())
+	}
+	return IPv6Prefix{Addr: MustU128FromBigEndian(ip), Bits: ones}, nil
+}
+
+// IsValid reports whether Bits is between 0 and 128.
+func (p IPv6Prefix) IsValid() bool {
+	return p.Bits >= 0 && p.Bits <= 128
+}
+
+// IPNet converts p to a *net.IPNet.
+func (p IPv6Prefix) IPNet() *net.IPNet {
+	return &net.IPNet{IP: p.Addr.AsIP(), Mask: net.CIDRMask(p.Bits, 128)}
+}
+
+// Mask returns the network mask of p.
+func (p IPv6Prefix) Mask() U128 {
+	return IPv6Mask(p.Bits)
+}
+
+// Masked returns p with the host bits of Addr cleared.
+func (p IPv6Prefix) Masked() IPv6Prefix {
+	return IPv6Prefix{Addr: p.First(), Bits: p.Bits}
+}
+
+// First returns the lowest address in p, which is the network address.
+func (p IPv6Prefix) First() U128 {
+	return p.Addr.And(p.Mask())
+}
+
+// Last returns the highest address in p. It is the IPv6 equivalent of an
+// IPv4 broadcast address, although IPv6 does not have broadcast.
+func (p IPv6Prefix) Last() U128 {
+	return p.Addr.Or(p.Mask().Not())
+}
+
+// Contains reports whether addr is in p.
+func (p IPv6Prefix) Contains(addr U128) bool {
+	mask := p.Mask()
+	return addr.And(mask).Equal(p.Addr.And(mask))
+}
+
+// String formats p in CIDR notation, i.e. "2001:db8::/32". IPv4-mapped
+// addresses are written in hex, i.e. "::ffff:102:304/128", rather than as a
+// dotted quad, so the result can always be parsed by ParseIPv6Prefix.
+func (p IPv6Prefix) String() string {
+	return ipv6String(p.Addr) + "/" + strconv.Itoa(p.Bits)
+}
+
+// ipv6String formats addr as an IPv6 address. net.IP.String is used except
+// for IPv4-mapped addresses, which it would write as IPv4.
+func ipv6String(addr U128) string {
+	ip := addr.AsIP()
+	if ip.To4() == nil {
+		return ip.String()
+	}
+	b := make([]byte, 0, len("::ffff:ffff:ffff"))
+	b = append(b, "::ffff:"...)
+	b = strconv.AppendUint(b, uint64(ip[12])<<8|uint64(ip[13]), 16)
+	b = append(b, ':')
+	b = strconv.AppendUint(b, uint64(ip[14])<<8|uint64(ip[15]), 16)
+	return string(b)
+}
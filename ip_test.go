@@ -0,0 +1,217 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128FromIP(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out U128
+	}{
+		{"::", zeroU128},
+		{"::1", u64(1)},
+		{"2001:db8::1", u128s("0x20010db8000000000000000000000001")},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", MaxU128},
+		{"1.2.3.4", u128s("0x00000000000000000000ffff01020304")},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			ip := net.ParseIP(tc.in)
+			v, err := U128FromIP(ip)
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, v)
+			tt.MustAssert(ip.Equal(v.AsIP()))
+			tt.MustEqual(net.IPv6len, len(v.AsIP()))
+
+			if ip4 := ip.To4(); ip4 != nil {
+				v, err = U128FromIP(ip4)
+				tt.MustOK(err)
+				tt.MustEqual(tc.out, v)
+			}
+		})
+	}
+
+	tt := assert.WrapTB(t)
+	for _, ip := range []net.IP{nil, {1, 2, 3}, make(net.IP, 17)} {
+		_, err := U128FromIP(ip)
+		tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+	}
+}
+
+func TestIPv6Mask(t *testing.T) {
+	tt := assert.WrapTB(t)
+	tt.MustEqual(zeroU128, IPv6Mask(0))
+	tt.MustEqual(u128s("0x80000000000000000000000000000000"), IPv6Mask(1))
+	tt.MustEqual(u128s("0xffffffff000000000000000000000000"), IPv6Mask(32))
+	tt.MustEqual(u128s("0xffffffffffffffff0000000000000000"), IPv6Mask(64))
+	tt.MustEqual(u128s("0xffffffffffffffff8000000000000000"), IPv6Mask(65))
+	tt.MustEqual(MaxU128, IPv6Mask(128))
+
+	for bits := 0; bits <= 128; bits++ {
+		tt.MustEqual(net.CIDRMask(bits, 128), net.IPMask(IPv6Mask(bits).AsIP()))
+	}
+
+	for _, bits := range []int{-1, 129} {
+		func() {
+			defer func() { tt.MustAssert(recover() != nil) }()
+			IPv6Mask(bits)
+		}()
+	}
+}
+
+func TestIPv6Offset(t *testing.T) {
+	for idx, tc := range []struct {
+		addr    U128
+		off     I128
+		out     U128
+		inRange bool
+	}{
+		{zeroU128, i64(1), u64(1), true},
+		{u64(1), i64(-1), zeroU128, true},
+		{zeroU128, i64(-1), MaxU128, false},
+		{MaxU128, i64(1), zeroU128, false},
+		{MaxU128, i64(-1), MaxU128.Dec(), true},
+		{u64(maxUint64), i64(1), U128FromRaw(1, 0), true},
+		{U128FromRaw(1, 0), i64(-1), u64(maxUint64), true},
+		{zeroU128, MaxI128, MaxI128.AsU128(), true},
+		{MaxU128, MinI128, MaxI128.AsU128(), true},
+		{MaxI128.AsU128(), MinI128, MaxU128, false},
+	} {
+		t.Run(fmt.Sprintf("%d/%s%+d", idx, tc.addr, tc.off), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			out, inRange := IPv6Offset(tc.addr, tc.off)
+			tt.MustEqual(tc.out, out)
+			tt.MustEqual(tc.inRange, inRange)
+		})
+	}
+}
+
+func TestIPv6Distance(t *testing.T) {
+	tt := assert.WrapTB(t)
+	a, _ := U128FromIP(net.ParseIP("2001:db8::1"))
+	b, _ := U128FromIP(net.ParseIP("2001:db8::1:0"))
+	tt.MustEqual(u64(0xffff), IPv6Distance(a, b))
+	tt.MustEqual(u64(0xffff), IPv6Distance(b, a))
+	tt.MustEqual(MaxU128, IPv6Distance(zeroU128, MaxU128))
+}
+
+func TestIPv6Prefix(t *testing.T) {
+	for idx, tc := range []struct {
+		in     string
+		str    string
+		masked string
+		first  string
+		last   string
+	}{
+		{"2001:db8::1/32", "2001:db8::1/32", "2001:db8::/32", "2001:db8::", "2001:db8:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"2001:db8:1:2::/64", "2001:db8:1:2::/64", "2001:db8:1:2::/64", "2001:db8:1:2::", "2001:db8:1:2:ffff:ffff:ffff:ffff"},
+		{"2001:db8::1/128", "2001:db8::1/128", "2001:db8::1/128", "2001:db8::1", "2001:db8::1"},
+		{"2001:db8::1/0", "2001:db8::1/0", "::/0", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+		{"fe80::/10", "fe80::/10", "fe80::/10", "fe80::", "febf:ffff:ffff:ffff:ffff:ffff:ffff:ffff"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			p, err := ParseIPv6Prefix(tc.in)
+			tt.MustOK(err)
+			tt.MustAssert(p.IsValid())
+			tt.MustEqual(tc.str, p.String())
+			tt.MustEqual(tc.masked, p.Masked().String())
+			tt.MustEqual(tc.first, p.First().AsIP().String())
+			tt.MustEqual(tc.last, p.Last().AsIP().String())
+
+			tt.MustAssert(p.Contains(p.Addr))
+			tt.MustAssert(p.Contains(p.First()))
+			tt.MustAssert(p.Contains(p.Last()))
+			if !p.First().IsZero() {
+				tt.MustAssert(!p.Contains(p.First().Dec()))
+			}
+			if p.Last() != MaxU128 {
+				tt.MustAssert(!p.Contains(p.Last().Inc()))
+			}
+
+			_, n, err := net.ParseCIDR(tc.in)
+			tt.MustOK(err)
+			tt.MustEqual(n.Mask, p.IPNet().Mask)
+			tt.MustAssert(p.IPNet().IP.Equal(p.Addr.AsIP()))
+			tt.MustEqual(n.String(), p.Masked().IPNet().String())
+			fromNet, err := IPv6PrefixFromIPNet(n)
+			tt.MustOK(err)
+			tt.MustEqual(p.Masked(), fromNet)
+		})
+	}
+}
+
+func TestIPv6PrefixStringRoundTrip(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out string
+	}{
+		{"::/0", "::/0"},
+		{"::1/128", "::1/128"},
+		{"::ffff:0:0/96", "::ffff:0:0/96"},
+		{"::ffff:1.2.3.4/128", "::ffff:102:304/128"},
+		{"::ffff:ffff:ffff/128", "::ffff:ffff:ffff/128"},
+		{"::ffff:10.0.0.0/104", "::ffff:a00:0/104"},
+		{"::fffe:ffff:ffff/128", "::fffe:ffff:ffff/128"},
+		{"::1:ffff:0:0/96", "::1:ffff:0:0/96"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			p := mustParseIPv6Prefix(tt, tc.in)
+			tt.MustEqual(tc.out, p.String())
+
+			rt, err := ParseIPv6Prefix(p.String())
+			tt.MustOK(err)
+			tt.MustEqual(p, rt)
+		})
+	}
+
+	// Every subnet of the IPv4-mapped range must survive a round trip:
+	tt := assert.WrapTB(t)
+	it, err := mustParseIPv6Prefix(tt, "::ffff:0:0/96").Subnets(104)
+	tt.MustOK(err)
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		rt, err := ParseIPv6Prefix(p.String())
+		tt.MustOK(err)
+		tt.MustEqual(p, rt)
+	}
+
+	scratch := make([]byte, 16)
+	mapped := mustParseIPv6Prefix(tt, "::ffff:0:0/96")
+	for i := 0; i < 1000; i++ {
+		p := IPv6Prefix{Addr: randU128(scratch), Bits: globalRNG.Intn(129)}
+		if i%2 == 0 {
+			p.Addr = mapped.Addr.Or(p.Addr.And(mapped.Mask().Not()))
+		}
+		rt, err := ParseIPv6Prefix(p.String())
+		tt.MustOK(err)
+		tt.MustEqual(p, rt)
+	}
+}
+
+func TestParseIPv6PrefixErrors(t *testing.T) {
+	for idx, in := range []string{
+		"",
+		"2001:db8::1",
+		"2001:db8::1/129",
+		"2001:db8::1/-1",
+		"1.2.3.4/24",
+		"2001:db8::g/32",
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			_, err := ParseIPv6Prefix(in)
+			tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+		})
+	}
+
+	tt := assert.WrapTB(t)
+	_, err := IPv6PrefixFromIPNet(&net.IPNet{IP: net.ParseIP("1.2.3.4"), Mask: net.CIDRMask(24, 32)})
+	tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+}
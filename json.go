@@ -0,0 +1,221 @@
+package num
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonUnwrap extracts the number literal from a JSON value passed to
+// UnmarshalJSON, which may be either a bare number or a string.
+func jsonUnwrap(bts []byte) (s string, null bool, ok bool) {
+	bts = bytes.TrimSpace(bts)
+	if len(bts) == 0 {
+		return "", false, false
+	}
+	if bytes.Equal(bts, []byte("null")) {
+		return "", true, true
+	}
+	if bts[0] == '"' {
+		if err := json.Unmarshal(bts, &s); err != nil {
+			return "", false, false
+		}
+		return s, false, true
+	}
+	return string(bts), false, true
+}
+
+func (u *U128) unmarshalJSON(fn string, bts []byte, clamp bool) (err error) {
+	s, null, ok := jsonUnwrap(bts)
+	if !ok {
+		return syntaxError(fn, string(bts))
+	} else if null {
+		return nil
+	}
+
+	neg, mag, inRange, ok := parseNumberLiteral(s)
+	if !ok {
+		return syntaxError(fn, string(bts))
+	}
+	v, inRange := u128FromLiteral(neg, mag, inRange)
+	if !inRange && !clamp {
+		return rangeError(fn, string(bts))
+	}
+	*u = v
+	return nil
+}
+
+func (i *I128) unmarshalJSON(fn string, bts []byte, clamp bool) (err error) {
+	s, null, ok := jsonUnwrap(bts)
+	if !ok {
+		return syntaxError(fn, string(bts))
+	} else if null {
+		return nil
+	}
+
+	neg, mag, inRange, ok := parseNumberLiteral(s)
+	if !ok {
+		return syntaxError(fn, string(bts))
+	}
+	v, inRange := i128FromLiteral(neg, mag, inRange)
+	if !inRange && !clamp {
+		return rangeError(fn, string(bts))
+	}
+	*i = v
+	return nil
+}
+
+func (u *U128) unmarshalLiteral(fn string, bts []byte) (err error) {
+	neg, mag, inRange, ok := parseNumberLiteral(string(bts))
+	if !ok {
+		return syntaxError(fn, string(bts))
+	}
+	v, inRange := u128FromLiteral(neg, mag, inRange)
+	if !inRange {
+		return rangeError(fn, string(bts))
+	}
+	*u = v
+	return nil
+}
+
+func (i *I128) unmarshalLiteral(fn string, bts []byte) (err error) {
+	neg, mag, inRange, ok := parseNumberLiteral(string(bts))
+	if !ok {
+		return syntaxError(fn, string(bts))
+	}
+	v, inRange := i128FromLiteral(neg, mag, inRange)
+	if !inRange {
+		return rangeError(fn, string(bts))
+	}
+	*i = v
+	return nil
+}
+
+func appendHexU128(dst []byte, u U128) []byte {
+	return appendRaw(dst, u.hi, u.lo, 4, false)
+}
+
+// U128Number is a U128 that marshals to JSON as a bare number rather than a
+// string, i.e. 1234 instead of "1234". Be aware that many JSON decoders will
+// lose precision when decoding numbers larger than 1<<53.
+//
+// UnmarshalJSON accepts anything U128.UnmarshalJSON does.
+type U128Number U128
+
+// U128 returns the value as a U128.
+func (u U128Number) U128() U128 { return U128(u) }
+
+func (u U128Number) String() string { return U128(u).String() }
+
+func (u U128Number) Format(s fmt.State, c rune) { U128(u).Format(s, c) }
+
+func (u U128Number) MarshalText() ([]byte, error) { return U128(u).MarshalText() }
+
+func (u *U128Number) UnmarshalText(bts []byte) (err error) {
+	return (*U128)(u).unmarshalLiteral("U128Number.UnmarshalText", bts)
+}
+
+func (u U128Number) MarshalJSON() ([]byte, error) {
+	return []byte(U128(u).String()), nil
+}
+
+func (u *U128Number) UnmarshalJSON(bts []byte) (err error) {
+	return (*U128)(u).unmarshalJSON("U128Number.UnmarshalJSON", bts, false)
+}
+
+// U128Hex is a U128 that marshals to JSON as a "0x"-prefixed hex string, i.e.
+// "0x4d2" instead of "1234".
+//
+// UnmarshalJSON accepts anything U128.UnmarshalJSON does.
+type U128Hex U128
+
+// U128 returns the value as a U128.
+func (u U128Hex) U128() U128 { return U128(u) }
+
+func (u U128Hex) String() string { return U128(u).String() }
+
+func (u U128Hex) Format(s fmt.State, c rune) { U128(u).Format(s, c) }
+
+func (u U128Hex) MarshalText() ([]byte, error) {
+	return appendHexU128([]byte("0x"), U128(u)), nil
+}
+
+func (u *U128Hex) UnmarshalText(bts []byte) (err error) {
+	return (*U128)(u).unmarshalLiteral("U128Hex.UnmarshalText", bts)
+}
+
+func (u U128Hex) MarshalJSON() ([]byte, error) {
+	out := appendHexU128([]byte(`"0x`), U128(u))
+	return append(out, '"'), nil
+}
+
+func (u *U128Hex) UnmarshalJSON(bts []byte) (err error) {
+	return (*U128)(u).unmarshalJSON("U128Hex.UnmarshalJSON", bts, false)
+}
+
+// I128Number is an I128 that marshals to JSON as a bare number rather than a
+// string, i.e. -1234 instead of "-1234". Be aware that many JSON decoders will
+// lose precision when decoding numbers outside +/- 1<<53.
+//
+// UnmarshalJSON accepts anything I128.UnmarshalJSON does.
+type I128Number I128
+
+// I128 returns the value as an I128.
+func (i I128Number) I128() I128 { return I128(i) }
+
+func (i I128Number) String() string { return I128(i).String() }
+
+func (i I128Number) Format(s fmt.State, c rune) { I128(i).Format(s, c) }
+
+func (i I128Number) MarshalText() ([]byte, error) { return I128(i).MarshalText() }
+
+func (i *I128Number) UnmarshalText(bts []byte) (err error) {
+	return (*I128)(i).unmarshalLiteral("I128Number.UnmarshalText", bts)
+}
+
+func (i I128Number) MarshalJSON() ([]byte, error) {
+	return []byte(I128(i).String()), nil
+}
+
+func (i *I128Number) UnmarshalJSON(bts []byte) (err error) {
+	return (*I128)(i).unmarshalJSON("I128Number.UnmarshalJSON", bts, false)
+}
+
+// I128Hex is an I128 that marshals to JSON as a "0x"-prefixed hex string, i.e.
+// "-0x4d2" instead of "-1234". Negative numbers are written as a sign followed
+// by the hex magnitude, not as a two's complement bit pattern.
+//
+// UnmarshalJSON accepts anything I128.UnmarshalJSON does.
+type I128Hex I128
+
+// I128 returns the value as an I128.
+func (i I128Hex) I128() I128 { return I128(i) }
+
+func (i I128Hex) String() string { return I128(i).String() }
+
+func (i I128Hex) Format(s fmt.State, c rune) { I128(i).Format(s, c) }
+
+func (i I128Hex) MarshalText() ([]byte, error) {
+	return i.appendHex(nil), nil
+}
+
+func (i *I128Hex) UnmarshalText(bts []byte) (err error) {
+	return (*I128)(i).unmarshalLiteral("I128Hex.UnmarshalText", bts)
+}
+
+func (i I128Hex) MarshalJSON() ([]byte, error) {
+	out := i.appendHex([]byte{'"'})
+	return append(out, '"'), nil
+}
+
+func (i *I128Hex) UnmarshalJSON(bts []byte) (err error) {
+	return (*I128)(i).unmarshalJSON("I128Hex.UnmarshalJSON", bts, false)
+}
+
+func (i I128Hex) appendHex(dst []byte) []byte {
+	if I128(i).Sign() < 0 {
+		dst = append(dst, '-')
+	}
+	dst = append(dst, "0x"...)
+	return appendHexU128(dst, I128(i).AbsU128())
+}
@@ -0,0 +1,203 @@
+package num
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestJSONWrappersMarshal(t *testing.T) {
+	for idx, tc := range []struct {
+		in  interface{}
+		out string
+	}{
+		{U128Number(zeroU128), `0`},
+		{U128Number(MaxU128), `340282366920938463463374607431768211455`},
+		{U128Hex(zeroU128), `"0x0"`},
+		{U128Hex(u64(0xabc)), `"0xabc"`},
+		{U128Hex(u128s("0x1 0000000000000001")), `"0x10000000000000001"`},
+		{U128Hex(MaxU128), `"0xffffffffffffffffffffffffffffffff"`},
+		{I128Number(i64(-1234)), `-1234`},
+		{I128Number(MinI128), `-170141183460469231731687303715884105728`},
+		{I128Hex(i64(-1)), `"-0x1"`},
+		{I128Hex(i64(255)), `"0xff"`},
+		{I128Hex(MinI128), `"-0x80000000000000000000000000000000"`},
+		{I128Hex(MaxI128), `"0x7fffffffffffffffffffffffffffffff"`},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.out), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, err := json.Marshal(tc.in)
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, string(bts))
+		})
+	}
+}
+
+func TestJSONWrappersRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+
+	type encoded struct {
+		UN U128Number
+		UH U128Hex
+		IN I128Number
+		IH I128Hex
+	}
+
+	for i := 0; i < 2000; i++ {
+		u := randU128(scratch)
+		n := randI128(scratch)
+		if i%2 == 0 {
+			n = n.Neg()
+		}
+		in := encoded{UN: U128Number(u), UH: U128Hex(u), IN: I128Number(n), IH: I128Hex(n)}
+		bts, err := json.Marshal(in)
+		tt.MustOK(err)
+
+		var out encoded
+		tt.MustOK(json.Unmarshal(bts, &out))
+		tt.MustEqual(in, out)
+
+		// Text marshalling is used for map keys:
+		m := map[U128Hex]I128Hex{U128Hex(u): I128Hex(n)}
+		bts, err = json.Marshal(m)
+		tt.MustOK(err)
+		var mout map[U128Hex]I128Hex
+		tt.MustOK(json.Unmarshal(bts, &mout))
+		tt.MustEqual(m, mout)
+	}
+}
+
+func TestU128UnmarshalJSONForms(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out U128
+		err error
+	}{
+		{`1`, u64(1), nil},
+		{`"1"`, u64(1), nil},
+		{` 1 `, u64(1), nil},
+		{`"0x1f"`, u64(0x1f), nil},
+		{`"0XFF"`, u64(0xff), nil},
+		{`"0xffffffffffffffffffffffffffffffff"`, MaxU128, nil},
+		{`"0x100000000000000000000000000000000"`, zeroU128, ErrRange},
+		{`1e20`, u128s("100000000000000000000"), nil},
+		{`1E+20`, u128s("100000000000000000000"), nil},
+		{`"1e20"`, u128s("100000000000000000000"), nil},
+		{`1.5e1`, u64(15), nil},
+		{`1500e-2`, u64(15), nil},
+		{`1.0`, u64(1), nil},
+		{`0.0`, zeroU128, nil},
+		{`0e999999999999`, zeroU128, nil},
+		{`3.40282366920938463463374607431768211455e38`, MaxU128, nil},
+		{`3.40282366920938463463374607431768211456e38`, zeroU128, ErrRange},
+		{`1e39`, zeroU128, ErrRange},
+		{`1e999999999999`, zeroU128, ErrRange},
+		{`-0`, zeroU128, nil},
+		{`-1`, zeroU128, ErrRange},
+		{`-1e1`, zeroU128, ErrRange},
+		{`1.5`, zeroU128, ErrSyntax},
+		{`15e-1`, zeroU128, ErrSyntax},
+		{`1e-999999999999`, zeroU128, ErrSyntax},
+		{`1.`, zeroU128, ErrSyntax},
+		{`.1`, zeroU128, ErrSyntax},
+		{`1e`, zeroU128, ErrSyntax},
+		{`1e+`, zeroU128, ErrSyntax},
+		{`"0x"`, zeroU128, ErrSyntax},
+		{`"0xg"`, zeroU128, ErrSyntax},
+		{`""`, zeroU128, ErrSyntax},
+		{`"`, zeroU128, ErrSyntax},
+		{`"1`, zeroU128, ErrSyntax},
+		{``, zeroU128, ErrSyntax},
+		{`  `, zeroU128, ErrSyntax},
+		{`true`, zeroU128, ErrSyntax},
+		{`[1]`, zeroU128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			for _, v := range []interface{ UnmarshalJSON([]byte) error }{
+				new(U128), new(U128Number), new(U128Hex),
+			} {
+				err := v.UnmarshalJSON([]byte(tc.in))
+				if tc.err != nil {
+					tt.MustAssert(errors.Is(err, tc.err), "%T: %v", v, err)
+					continue
+				}
+				tt.MustOK(err)
+				var result U128
+				switch v := v.(type) {
+				case *U128:
+					result = *v
+				case *U128Number:
+					result = v.U128()
+				case *U128Hex:
+					result = v.U128()
+				}
+				tt.MustEqual(tc.out, result, "%T", v)
+			}
+		})
+	}
+}
+
+func TestI128UnmarshalJSONForms(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out I128
+		err error
+	}{
+		{`-1`, i64(-1), nil},
+		{`"-1"`, i64(-1), nil},
+		{`"+1"`, i64(1), nil},
+		{`"-0x1f"`, i64(-0x1f), nil},
+		{`"-0x80000000000000000000000000000000"`, MinI128, nil},
+		{`"-0x80000000000000000000000000000001"`, zeroI128, ErrRange},
+		{`"0x80000000000000000000000000000000"`, zeroI128, ErrRange},
+		{`-1.5e1`, i64(-15), nil},
+		{`-1e38`, i128s("-100000000000000000000000000000000000000"), nil},
+		{`-1e39`, zeroI128, ErrRange},
+		{`-1.5`, zeroI128, ErrSyntax},
+		{`--1`, zeroI128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			for _, v := range []interface{ UnmarshalJSON([]byte) error }{
+				new(I128), new(I128Number), new(I128Hex),
+			} {
+				err := v.UnmarshalJSON([]byte(tc.in))
+				if tc.err != nil {
+					tt.MustAssert(errors.Is(err, tc.err), "%T: %v", v, err)
+					continue
+				}
+				tt.MustOK(err)
+				var result I128
+				switch v := v.(type) {
+				case *I128:
+					result = *v
+				case *I128Number:
+					result = v.I128()
+				case *I128Hex:
+					result = v.I128()
+				}
+				tt.MustEqual(tc.out, result, "%T", v)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONNull(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	v := struct {
+		U  U128
+		I  I128
+		UN U128Number
+		IH I128Hex
+	}{U: u64(1), I: i64(-1), UN: U128Number(u64(2)), IH: I128Hex(i64(-2))}
+	orig := v
+
+	tt.MustOK(json.Unmarshal([]byte(`{"U":null,"I":null,"UN":null,"IH":null}`), &v))
+	tt.MustEqual(orig, v)
+}
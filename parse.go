@@ -0,0 +1,199 @@
+package num
+
+import (
+	"math/bits"
+)
+
+// parseMagnitude parses an unsigned string of digits in the given base (2 to
+// 36) without a sign or prefix. If the value overflows a U128, MaxU128 is
+// returned and inRange is set to false; parsing continues so that syntax
+// errors are still detected.
+func parseMagnitude(s string, base uint64) (out U128, inRange bool, ok bool) {
+	if len(s) == 0 {
+		return out, false, false
+	}
+
+	inRange = true
+	for i := 0; i < len(s); i++ {
+		d := digitVal(s[i])
+		if d >= base {
+			return U128{}, false, false
+		}
+		if !inRange {
+			continue
+		}
+
+		if out, inRange = mulAdd64(out, base, d); !inRange {
+			out = MaxU128
+		}
+	}
+	return out, inRange, true
+}
+
+// mulAdd64 returns u*m + a. If the result overflows a U128, ok is false.
+func mulAdd64(u U128, m, a uint64) (out U128, ok bool) {
+	hiHi, hiLo := bits.Mul64(u.hi, m)
+	loHi, loLo := bits.Mul64(u.lo, m)
+	hi, carry := bits.Add64(hiLo, loHi, 0)
+	if hiHi != 0 || carry != 0 {
+		return out, false
+	}
+	out.lo, carry = bits.Add64(loLo, a, 0)
+	out.hi, carry = bits.Add64(hi, 0, carry)
+	return out, carry == 0
+}
+
+func digitVal(c byte) uint64 {
+	switch {
+	case '0' <= c && c <= '9':
+		return uint64(c - '0')
+	case 'a' <= c && c <= 'z':
+		return uint64(c - 'a' + 10)
+	case 'A' <= c && c <= 'Z':
+		return uint64(c - 'A' + 10)
+	}
+	return 255
+}
+
+// maxU128Digits is the number of decimal digits in MaxU128.
+const maxU128Digits = 39
+
+// parseNumberLiteral parses the forms of number accepted by UnmarshalJSON:
+// an optional sign followed by either a "0x"-prefixed hex integer, or a
+// decimal number with an optional fraction and exponent (i.e. "1.5e3").
+// Decimal numbers must have an exact integer value.
+//
+// The sign is returned separately from the magnitude. If the magnitude
+// overflows a U128, inRange is false.
+func parseNumberLiteral(s string) (neg bool, mag U128, inRange bool, ok bool) {
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if len(s) > 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		mag, inRange, ok = parseMagnitude(s[2:], 16)
+		return neg, mag, inRange, ok
+	}
+
+	var intPart, fracPart, expPart string
+	intPart = s
+	for i := 0; i < len(s); i++ {
+		if s[i] == 'e' || s[i] == 'E' {
+			intPart, expPart = s[:i], s[i+1:]
+			if expPart == "" {
+				return neg, mag, false, false
+			}
+			break
+		}
+	}
+	for i := 0; i < len(intPart); i++ {
+		if intPart[i] == '.' {
+			intPart, fracPart = intPart[:i], intPart[i+1:]
+			if fracPart == "" {
+				return neg, mag, false, false
+			}
+			break
+		}
+	}
+	if intPart == "" || !isDecimalDigits(intPart) || !isDecimalDigits(fracPart) {
+		return neg, mag, false, false
+	}
+
+	exp := 0
+	if expPart != "" {
+		expNeg := false
+		if expPart[0] == '-' || expPart[0] == '+' {
+			expNeg = expPart[0] == '-'
+			expPart = expPart[1:]
+		}
+		if expPart == "" || !isDecimalDigits(expPart) {
+			return neg, mag, false, false
+		}
+		for i := 0; i < len(expPart); i++ {
+			if exp < 1e6 { // Large enough to overflow any non-zero value; avoids int overflow.
+				exp = exp*10 + int(expPart[i]-'0')
+			}
+		}
+		if expNeg {
+			exp = -exp
+		}
+	}
+
+	digits := trimLeadingZeros(intPart + fracPart)
+	exp -= len(fracPart)
+
+	if digits == "" {
+		return neg, zeroU128, true, true
+	}
+
+	if exp < 0 {
+		// Any digits that end up after the decimal point must be zero:
+		cut := len(digits) + exp
+		if cut < 0 {
+			return neg, mag, false, false
+		}
+		for i := cut; i < len(digits); i++ {
+			if digits[i] != '0' {
+				return neg, mag, false, false
+			}
+		}
+		digits = digits[:cut]
+		if digits == "" {
+			return neg, zeroU128, true, true
+		}
+
+	} else if exp > 0 {
+		if len(digits)+exp > maxU128Digits {
+			return neg, MaxU128, false, true
+		}
+		zeros := make([]byte, exp)
+		for i := range zeros {
+			zeros[i] = '0'
+		}
+		digits += string(zeros)
+	}
+
+	mag, inRange, ok = parseMagnitude(digits, 10)
+	return neg, mag, inRange, ok
+}
+
+func isDecimalDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func trimLeadingZeros(s string) string {
+	for len(s) > 0 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
+
+// u128FromLiteral converts the result of parseNumberLiteral to a U128,
+// clamping to 0 or MaxU128 and setting inRange to false if it does not fit.
+func u128FromLiteral(neg bool, mag U128, inRange bool) (U128, bool) {
+	if neg && !mag.IsZero() {
+		return zeroU128, false
+	}
+	return mag, inRange
+}
+
+// i128FromLiteral converts the result of parseNumberLiteral to an I128,
+// clamping to MinI128 or MaxI128 and setting inRange to false if it does not
+// fit.
+func i128FromLiteral(neg bool, mag U128, inRange bool) (I128, bool) {
+	if neg {
+		if !inRange || mag.GreaterThan(minI128AsAbsU128) {
+			return MinI128, false
+		}
+		return mag.AsI128().Neg(), true
+	}
+	if !inRange || mag.GreaterThan(maxI128AsU128) {
+		return MaxI128, false
+	}
+	return mag.AsI128(), true
+}
@@ -0,0 +1,56 @@
+package num
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestParseMagnitude(t *testing.T) {
+	for _, base := range []int{2, 8, 10, 16, 36} {
+		t.Run(fmt.Sprintf("random/%d", base), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			for i := 0; i < 1000; i++ {
+				b := randomBigU128(nil)
+				s := b.Text(base)
+				u, inRange, ok := parseMagnitude(s, uint64(base))
+				tt.MustAssert(ok && inRange)
+				tt.MustEqual(b.String(), u.String())
+			}
+		})
+
+		t.Run(fmt.Sprintf("overflow/%d", base), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			s := new(big.Int).Add(maxBigU128, big1).Text(base)
+			u, inRange, ok := parseMagnitude(s, uint64(base))
+			tt.MustAssert(ok)
+			tt.MustAssert(!inRange)
+			tt.MustEqual(MaxU128, u)
+
+			s = maxBigU128.Text(base)
+			u, inRange, ok = parseMagnitude(s, uint64(base))
+			tt.MustAssert(ok && inRange)
+			tt.MustEqual(MaxU128, u)
+		})
+	}
+
+	for idx, tc := range []struct {
+		in   string
+		base uint64
+	}{
+		{"", 10},
+		{"12a", 10},
+		{"2", 2},
+		{"-1", 10},
+		{"g", 16},
+		{"1_000", 10},
+	} {
+		t.Run(fmt.Sprintf("invalid/%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			_, _, ok := parseMagnitude(tc.in, tc.base)
+			tt.MustAssert(!ok)
+		})
+	}
+}
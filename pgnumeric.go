@@ -0,0 +1,174 @@
+package num
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// PostgreSQL's binary NUMERIC format is a header of four 16-bit big-endian
+// fields followed by ndigits base-10000 digits, most significant first:
+//
+//	ndigits int16   number of base-10000 digits that follow
+//	weight  int16   power of 10000 of the first digit
+//	sign    uint16  pgNumericPos, pgNumericNeg, or a special value
+//	dscale  uint16  number of decimal digits after the point
+//
+// The value is the sum of digit[i] * 10000^(weight-i). Postgres strips
+// leading and trailing zero digits, so zero has no digits at all.
+const (
+	pgNumericPos    = 0x0000
+	pgNumericNeg    = 0x4000
+	pgNumericNaN    = 0xC000
+	pgNumericPosInf = 0xD000
+	pgNumericNegInf = 0xF000
+
+	pgNumericBase       = 10000
+	pgNumericHeaderSize = 8
+
+	// 10000^10 > MaxU128, so no more than 10 digits are needed:
+	pgNumericMaxDigits = 10
+)
+
+var (
+	errPGNumericNaN      = fmt.Errorf("%w: NUMERIC is NaN", ErrRange)
+	errPGNumericInf      = fmt.Errorf("%w: NUMERIC is infinite", ErrRange)
+	errPGNumericFraction = fmt.Errorf("%w: NUMERIC has a fractional part", ErrRange)
+)
+
+// AppendPGNumeric appends u to dst in PostgreSQL's binary NUMERIC format, as
+// used by the binary wire protocol and COPY BINARY, and returns the extended
+// buffer. The display scale is always 0.
+func (u U128) AppendPGNumeric(dst []byte) []byte {
+	return appendPGNumeric(dst, pgNumericPos, u)
+}
+
+// AppendPGNumeric appends i to dst in PostgreSQL's binary NUMERIC format, as
+// used by the binary wire protocol and COPY BINARY, and returns the extended
+// buffer. The display scale is always 0.
+func (i I128) AppendPGNumeric(dst []byte) []byte {
+	if i.hi&signBit != 0 {
+		return appendPGNumeric(dst, pgNumericNeg, i.AbsU128())
+	}
+	return appendPGNumeric(dst, pgNumericPos, i.AsU128())
+}
+
+func appendPGNumeric(dst []byte, sign uint16, mag U128) []byte {
+	// Digits are collected least significant first:
+	var digits [pgNumericMaxDigits]uint16
+	n := 0
+	for !mag.IsZero() {
+		var r U128
+		mag, r = mag.QuoRem64(pgNumericBase)
+		digits[n] = uint16(r.lo)
+		n++
+	}
+	weight := n - 1
+
+	trailing := 0
+	for trailing < n && digits[trailing] == 0 {
+		trailing++
+	}
+	if n == 0 {
+		weight, sign = 0, pgNumericPos
+	}
+
+	var hdr [pgNumericHeaderSize]byte
+	binary.BigEndian.PutUint16(hdr[0:], uint16(n-trailing))
+	binary.BigEndian.PutUint16(hdr[2:], uint16(weight))
+	binary.BigEndian.PutUint16(hdr[4:], sign)
+	binary.BigEndian.PutUint16(hdr[6:], 0)
+	dst = append(dst, hdr[:]...)
+	for i := n - 1; i >= trailing; i-- {
+		dst = append(dst, byte(digits[i]>>8), byte(digits[i]))
+	}
+	return dst
+}
+
+// U128FromPGNumeric decodes a U128 from PostgreSQL's binary NUMERIC format.
+//
+// Malformed input produces a *NumError wrapping ErrSyntax. Values that are
+// well formed but can't be represented as a U128, including negative
+// numbers, numbers with a non-zero fractional part, NaN and infinities,
+// produce a *NumError wrapping ErrRange. Trailing zeros after the decimal
+// point, i.e. 12.00, are permitted.
+func U128FromPGNumeric(b []byte) (out U128, err error) {
+	neg, mag, err := parsePGNumeric("U128FromPGNumeric", b)
+	if err != nil {
+		return out, err
+	}
+	out, inRange := u128FromLiteral(neg, mag, true)
+	if !inRange {
+		return zeroU128, rangeError("U128FromPGNumeric", hex.EncodeToString(b))
+	}
+	return out, nil
+}
+
+// I128FromPGNumeric decodes an I128 from PostgreSQL's binary NUMERIC format.
+// Errors are reported in the same way as U128FromPGNumeric.
+func I128FromPGNumeric(b []byte) (out I128, err error) {
+	neg, mag, err := parsePGNumeric("I128FromPGNumeric", b)
+	if err != nil {
+		return out, err
+	}
+	out, inRange := i128FromLiteral(neg, mag, true)
+	if !inRange {
+		return zeroI128, rangeError("I128FromPGNumeric", hex.EncodeToString(b))
+	}
+	return out, nil
+}
+
+func parsePGNumeric(fn string, b []byte) (neg bool, mag U128, err error) {
+	numErr := func(err error) *NumError {
+		return &NumError{Func: fn, Num: hex.EncodeToString(b), Err: err}
+	}
+
+	if len(b) < pgNumericHeaderSize {
+		return false, mag, numErr(ErrSyntax)
+	}
+	ndigits := int(int16(binary.BigEndian.Uint16(b[0:])))
+	weight := int(int16(binary.BigEndian.Uint16(b[2:])))
+	sign := binary.BigEndian.Uint16(b[4:])
+	if ndigits < 0 || len(b) != pgNumericHeaderSize+2*ndigits {
+		return false, mag, numErr(ErrSyntax)
+	}
+
+	switch sign {
+	case pgNumericPos:
+	case pgNumericNeg:
+		neg = true
+	case pgNumericNaN:
+		return false, mag, numErr(errPGNumericNaN)
+	case pgNumericPosInf, pgNumericNegInf:
+		return false, mag, numErr(errPGNumericInf)
+	default:
+		return false, mag, numErr(ErrSyntax)
+	}
+
+	ok := true
+	for i := 0; i < ndigits; i++ {
+		d := binary.BigEndian.Uint16(b[pgNumericHeaderSize+2*i:])
+		if d >= pgNumericBase {
+			return false, mag, numErr(ErrSyntax)
+		}
+		if weight-i < 0 {
+			if d != 0 {
+				return false, mag, numErr(errPGNumericFraction)
+			}
+			continue
+		}
+		if ok {
+			mag, ok = mulAdd64(mag, pgNumericBase, uint64(d))
+		}
+	}
+
+	// Trailing zero digits are not stored:
+	for e := weight - ndigits + 1; e > 0 && ok && !mag.IsZero(); e-- {
+		mag, ok = mulAdd64(mag, pgNumericBase, 0)
+	}
+
+	if !ok {
+		return false, mag, numErr(ErrRange)
+	}
+	return neg, mag, nil
+}
@@ -0,0 +1,149 @@
+package num
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+// Fixtures are the bytes Postgres sends for SELECT <value>::numeric in binary
+// format.
+
+func TestU128PGNumeric(t *testing.T) {
+	for idx, tc := range []struct {
+		in  U128
+		out string
+	}{
+		{zeroU128, "0000000000000000"},
+		{u64(1), "00010000000000000001"},
+		{u64(9999), "0001000000000000270f"},
+		{u64(10000), "00010001000000000001"},
+		{u64(12345), "000200010000000000010929"},
+		{u64(100000000), "00010002000000000001"},
+		{u64(maxUint64), "000500040000000007341a5802e103bb064f"},
+		{MaxU128, "000a00090000000001540b071a2403aa121a18c111ff10dd1aa505af"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, hex.EncodeToString(tc.in.AppendPGNumeric(nil)))
+
+			bts, _ := hex.DecodeString(tc.out)
+			v, err := U128FromPGNumeric(bts)
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestI128PGNumeric(t *testing.T) {
+	for idx, tc := range []struct {
+		in  I128
+		out string
+	}{
+		{zeroI128, "0000000000000000"},
+		{i64(-1), "00010000400000000001"},
+		{i64(-12345), "000200014000000000010929"},
+		{MaxI128, "000a00090000000000aa0583209a01d5090d0c601c871bf620da165f"},
+		{MinI128, "000a00094000000000aa0583209a01d5090d0c601c871bf620da1660"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, hex.EncodeToString(tc.in.AppendPGNumeric(nil)))
+
+			bts, _ := hex.DecodeString(tc.out)
+			v, err := I128FromPGNumeric(bts)
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestPGNumericDecode(t *testing.T) {
+	for idx, tc := range []struct {
+		in   string
+		u    U128
+		uErr error
+		i    I128
+		iErr error
+	}{
+		// 12.00, as stored in a NUMERIC(10,2) column:
+		{"0001000000000002000c", u64(12), nil, i64(12), nil},
+		// 1.5:
+		{"000200000000000100011388", zeroU128, errPGNumericFraction, zeroI128, errPGNumericFraction},
+		// 0.0001:
+		{"0001ffff000000040001", zeroU128, errPGNumericFraction, zeroI128, errPGNumericFraction},
+		// -0:
+		{"0000000040000000", zeroU128, nil, zeroI128, nil},
+		// -1:
+		{"00010000400000000001", zeroU128, ErrRange, i64(-1), nil},
+		// 1e40:
+		{"0001000a000000000001", zeroU128, ErrRange, zeroI128, ErrRange},
+		// MaxU128 + 1:
+		{"000a00090000000001540b071a2403aa121a18c111ff10dd1aa505b0", zeroU128, ErrRange, zeroI128, ErrRange},
+		// MaxI128 + 1:
+		{"000a00090000000000aa0583209a01d5090d0c601c871bf620da1660", u64(1).Lsh(127), nil, zeroI128, ErrRange},
+		// NaN, Infinity, -Infinity:
+		{"00000000c0000000", zeroU128, errPGNumericNaN, zeroI128, errPGNumericNaN},
+		{"00000000d0000000", zeroU128, errPGNumericInf, zeroI128, errPGNumericInf},
+		{"00000000f0000000", zeroU128, errPGNumericInf, zeroI128, errPGNumericInf},
+		// Malformed:
+		{"", zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"00010000000000", zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"0001000000000000", zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"000100000000000000", zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"ffff000000000000", zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"00010000000000002710", zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+		{"00010000123400000001", zeroU128, ErrSyntax, zeroI128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, _ := hex.DecodeString(tc.in)
+
+			u, err := U128FromPGNumeric(bts)
+			if tc.uErr != nil {
+				tt.MustAssert(errors.Is(err, tc.uErr), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.u, u)
+
+			i, err := I128FromPGNumeric(bts)
+			if tc.iErr != nil {
+				tt.MustAssert(errors.Is(err, tc.iErr), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.i, i)
+		})
+	}
+}
+
+func TestPGNumericFractionIsRangeError(t *testing.T) {
+	tt := assert.WrapTB(t)
+	bts, _ := hex.DecodeString("000200000000000100011388")
+	_, err := U128FromPGNumeric(bts)
+	tt.MustAssert(errors.Is(err, ErrRange))
+	tt.MustEqual(`num: U128FromPGNumeric: parsing "000200000000000100011388": value out of range: NUMERIC has a fractional part`, err.Error())
+}
+
+func TestPGNumericRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randU128(scratch)
+		ur, err := U128FromPGNumeric(u.AppendPGNumeric(nil))
+		tt.MustOK(err)
+		tt.MustEqual(u, ur)
+
+		v := randI128(scratch)
+		if i%2 == 0 {
+			v = v.Neg()
+		}
+		vr, err := I128FromPGNumeric(v.AppendPGNumeric(nil))
+		tt.MustOK(err)
+		tt.MustEqual(v, vr)
+	}
+}
@@ -0,0 +1,198 @@
+package num
+
+// PrefixTree is a compressed binary radix tree (a PATRICIA trie) that maps
+// prefixes of a U128, identified by a value and a length in bits, to
+// arbitrary values. It supports exact lookup, longest-prefix match and
+// ordered iteration, which makes it suitable for IPv6 routing tables and
+// similar lookups.
+//
+// Only the most significant bits bits of a key are significant; the rest are
+// ignored, so 2001:db8::1/32 and 2001:db8::/32 are the same key. Methods that
+// take a prefix length panic if it is not between 0 and 128.
+//
+// The zero value is an empty tree ready to use. PrefixTree is not safe for
+// concurrent use if any goroutine modifies it.
+type PrefixTree struct {
+	root *prefixNode
+	size int
+}
+
+type prefixNode struct {
+	key      U128 // with all but the first bits bits cleared
+	bits     int
+	value    interface{}
+	hasValue bool
+	child    [2]*prefixNode
+}
+
+// Len returns the number of prefixes in the tree.
+func (t *PrefixTree) Len() int { return t.size }
+
+// Insert sets the value for the prefix key/bits. If the prefix was already
+// present, its value is replaced and replaced is true.
+func (t *PrefixTree) Insert(key U128, bits int, value interface{}) (replaced bool) {
+	key = key.And(IPv6Mask(bits))
+	leaf := &prefixNode{key: key, bits: bits, value: value, hasValue: true}
+
+	np := &t.root
+	for {
+		n := *np
+		if n == nil {
+			*np = leaf
+			t.size++
+			return false
+		}
+
+		common := commonPrefixLen(n.key, n.bits, key, bits)
+		switch {
+		case common == n.bits && common == bits:
+			replaced = n.hasValue
+			if !replaced {
+				t.size++
+			}
+			n.value, n.hasValue = value, true
+			return replaced
+
+		case common == n.bits:
+			// n is a prefix of key, so key belongs somewhere below it:
+			np = &n.child[branchBit(key, n.bits)]
+
+		case common == bits:
+			// key is a prefix of n, so it replaces n, which becomes its child:
+			leaf.child[branchBit(n.key, bits)] = n
+			*np = leaf
+			t.size++
+			return false
+
+		default:
+			// key and n diverge before the end of either, so they need a new
+			// parent without a value at the point they diverge:
+			parent := &prefixNode{key: key.And(IPv6Mask(common)), bits: common}
+			parent.child[branchBit(key, common)] = leaf
+			parent.child[branchBit(n.key, common)] = n
+			*np = parent
+			t.size++
+			return false
+		}
+	}
+}
+
+// Get returns the value for the prefix key/bits. ok is false if the exact
+// prefix is not in the tree, even if a shorter prefix containing it is; use
+// LongestPrefix for that.
+func (t *PrefixTree) Get(key U128, bits int) (value interface{}, ok bool) {
+	key = key.And(IPv6Mask(bits))
+	n := t.root
+	for n != nil && commonPrefixLen(n.key, n.bits, key, bits) == n.bits {
+		if n.bits == bits {
+			return n.value, n.hasValue
+		}
+		n = n.child[branchBit(key, n.bits)]
+	}
+	return nil, false
+}
+
+// Delete removes the prefix key/bits from the tree, and returns its value.
+// ok is false if the prefix was not in the tree.
+func (t *PrefixTree) Delete(key U128, bits int) (value interface{}, ok bool) {
+	key = key.And(IPv6Mask(bits))
+
+	var parentp *(*prefixNode)
+	np := &t.root
+	for {
+		n := *np
+		if n == nil || commonPrefixLen(n.key, n.bits, key, bits) < n.bits {
+			return nil, false
+		}
+		if n.bits == bits {
+			break
+		}
+		parentp, np = np, &n.child[branchBit(key, n.bits)]
+	}
+
+	n := *np
+	if !n.hasValue {
+		return nil, false
+	}
+	value = n.value
+	n.value, n.hasValue = nil, false
+	t.size--
+
+	// Nodes without values are only needed where two branches diverge:
+	switch {
+	case n.child[0] != nil && n.child[1] != nil:
+		return value, true
+	case n.child[0] != nil:
+		*np = n.child[0]
+	case n.child[1] != nil:
+		*np = n.child[1]
+	default:
+		*np = nil
+		if parentp != nil {
+			parent := *parentp
+			if !parent.hasValue {
+				if parent.child[0] != nil {
+					*parentp = parent.child[0]
+				} else {
+					*parentp = parent.child[1]
+				}
+			}
+		}
+	}
+	return value, true
+}
+
+// LongestPrefix returns the longest prefix in the tree that contains addr,
+// and its value. ok is false if no prefix contains addr.
+func (t *PrefixTree) LongestPrefix(addr U128) (key U128, bits int, value interface{}, ok bool) {
+	n := t.root
+	for n != nil && commonPrefixLen(n.key, n.bits, addr, 128) == n.bits {
+		if n.hasValue {
+			key, bits, value, ok = n.key, n.bits, n.value, true
+		}
+		if n.bits == 128 {
+			break
+		}
+		n = n.child[branchBit(addr, n.bits)]
+	}
+	return key, bits, value, ok
+}
+
+// Walk calls fn for each prefix in the tree in order, stopping if fn returns
+// false. Prefixes are ordered by address, then by length, so a prefix is
+// visited before any longer prefixes it contains. Keys are passed to fn with
+// their host bits cleared.
+//
+// The tree must not be modified during the walk.
+func (t *PrefixTree) Walk(fn func(key U128, bits int, value interface{}) bool) {
+	t.root.walk(fn)
+}
+
+func (n *prefixNode) walk(fn func(key U128, bits int, value interface{}) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.hasValue && !fn(n.key, n.bits, n.value) {
+		return false
+	}
+	return n.child[0].walk(fn) && n.child[1].walk(fn)
+}
+
+// commonPrefixLen returns the number of leading bits that the prefixes a/aBits
+// and b/bBits have in common.
+func commonPrefixLen(a U128, aBits int, b U128, bBits int) int {
+	n := int(a.Xor(b).LeadingZeros())
+	if aBits < n {
+		n = aBits
+	}
+	if bBits < n {
+		n = bBits
+	}
+	return n
+}
+
+// branchBit returns the bit of key that follows the first bits bits, which
+// selects the child to descend to from a node of that length.
+func branchBit(key U128, bits int) uint {
+	return key.Bit(127 - bits)
+}
@@ -0,0 +1,186 @@
+package num
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+type prefixTreeEntry struct {
+	key   U128
+	bits  int
+	value interface{}
+}
+
+func prefixTreeEntries(t *PrefixTree) (out []prefixTreeEntry) {
+	t.Walk(func(key U128, bits int, value interface{}) bool {
+		out = append(out, prefixTreeEntry{key, bits, value})
+		return true
+	})
+	return out
+}
+
+func TestPrefixTree(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var tree PrefixTree
+
+	for _, s := range []string{"2001:db8::/32", "2001:db8:1::/48", "2001:db8:2::/48", "::/0", "2001:db8:1::1/128"} {
+		p := mustParseIPv6Prefix(tt, s)
+		tt.MustAssert(!tree.Insert(p.Addr, p.Bits, s))
+	}
+	tt.MustEqual(5, tree.Len())
+
+	p := mustParseIPv6Prefix(tt, "2001:db8::/32")
+	tt.MustAssert(tree.Insert(p.Addr, p.Bits, "replaced"))
+	tt.MustEqual(5, tree.Len())
+	v, ok := tree.Get(p.Addr, p.Bits)
+	tt.MustAssert(ok)
+	tt.MustEqual("replaced", v)
+
+	// Host bits are ignored:
+	v, ok = tree.Get(mustParseIPv6Prefix(tt, "2001:db8:1::ffff/48").Addr, 48)
+	tt.MustAssert(ok)
+	tt.MustEqual("2001:db8:1::/48", v)
+
+	// Prefixes that only exist as branch points are not found:
+	_, ok = tree.Get(mustParseIPv6Prefix(tt, "2001:db8::/46").Addr, 46)
+	tt.MustAssert(!ok)
+
+	for idx, tc := range []struct {
+		addr   string
+		prefix string
+	}{
+		{"2001:db8:1::1", "2001:db8:1::1/128"},
+		{"2001:db8:1::2", "2001:db8:1::/48"},
+		{"2001:db8:2:3::", "2001:db8:2::/48"},
+		{"2001:db8:3::", "2001:db8::/32"},
+		{"2001:db9::", "::/0"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.addr), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			addr := mustParseIPv6Prefix(tt, tc.addr+"/128").Addr
+			key, bits, _, ok := tree.LongestPrefix(addr)
+			tt.MustAssert(ok)
+			tt.MustEqual(tc.prefix, IPv6Prefix{key, bits}.String())
+		})
+	}
+
+	var walked []string
+	tree.Walk(func(key U128, bits int, value interface{}) bool {
+		walked = append(walked, IPv6Prefix{key, bits}.String())
+		return len(walked) < 4
+	})
+	tt.MustEqual([]string{"::/0", "2001:db8::/32", "2001:db8:1::/48", "2001:db8:1::1/128"}, walked)
+
+	v, ok = tree.Delete(mustParseIPv6Prefix(tt, "::/0").Addr, 0)
+	tt.MustAssert(ok)
+	tt.MustEqual("::/0", v)
+	_, ok = tree.Delete(zeroU128, 0)
+	tt.MustAssert(!ok)
+	_, _, _, ok = tree.LongestPrefix(mustParseIPv6Prefix(tt, "2001:db9::/128").Addr)
+	tt.MustAssert(!ok)
+	tt.MustEqual(4, tree.Len())
+}
+
+func TestPrefixTreeEmpty(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var tree PrefixTree
+	tt.MustEqual(0, tree.Len())
+	_, ok := tree.Get(zeroU128, 0)
+	tt.MustAssert(!ok)
+	_, _, _, ok = tree.LongestPrefix(MaxU128)
+	tt.MustAssert(!ok)
+	_, ok = tree.Delete(zeroU128, 0)
+	tt.MustAssert(!ok)
+	tt.MustEqual(0, len(prefixTreeEntries(&tree)))
+
+	defer func() { tt.MustAssert(recover() != nil) }()
+	tree.Insert(zeroU128, 129, nil)
+}
+
+func TestPrefixTreeFuzz(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+
+	// Keys are drawn from a small pool of addresses so that prefixes share
+	// enough bits to exercise branching, and are revisited by Delete:
+	pool := make([]U128, 8)
+	for i := range pool {
+		pool[i] = randU128(scratch)
+	}
+	bitsPool := []int{0, 1, 7, 8, 31, 32, 63, 64, 65, 127, 128}
+
+	type refKey struct {
+		key  U128
+		bits int
+	}
+	ref := map[refKey]int{}
+	var tree PrefixTree
+
+	for i := 0; i < 5000; i++ {
+		key := pool[globalRNG.Intn(len(pool))]
+		bits := bitsPool[globalRNG.Intn(len(bitsPool))]
+		rk := refKey{key.And(IPv6Mask(bits)), bits}
+
+		if globalRNG.Intn(3) == 0 {
+			v, ok := tree.Delete(key, bits)
+			rv, rok := ref[rk]
+			tt.MustEqual(rok, ok)
+			if rok {
+				tt.MustEqual(rv, v)
+			}
+			delete(ref, rk)
+		} else {
+			_, exists := ref[rk]
+			tt.MustEqual(exists, tree.Insert(key, bits, i))
+			ref[rk] = i
+		}
+		tt.MustEqual(len(ref), tree.Len())
+
+		// Exact lookups:
+		for _, bits := range bitsPool {
+			v, ok := tree.Get(key, bits)
+			rv, rok := ref[refKey{key.And(IPv6Mask(bits)), bits}]
+			tt.MustEqual(rok, ok)
+			if rok {
+				tt.MustEqual(rv, v)
+			}
+		}
+
+		// Longest-prefix match, against both pooled and arbitrary addresses:
+		for _, addr := range []U128{key, key.Xor(u64(1)), randU128(scratch)} {
+			bestBits, bestVal := -1, 0
+			for k, v := range ref {
+				if addr.And(IPv6Mask(k.bits)) == k.key && k.bits > bestBits {
+					bestBits, bestVal = k.bits, v
+				}
+			}
+			pkey, pbits, v, ok := tree.LongestPrefix(addr)
+			tt.MustEqual(bestBits >= 0, ok)
+			if ok {
+				tt.MustEqual(bestBits, pbits)
+				tt.MustEqual(addr.And(IPv6Mask(pbits)), pkey)
+				tt.MustEqual(bestVal, v)
+			}
+		}
+	}
+
+	var expected []prefixTreeEntry
+	for k, v := range ref {
+		expected = append(expected, prefixTreeEntry{k.key, k.bits, v})
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		if c := expected[i].key.Cmp(expected[j].key); c != 0 {
+			return c < 0
+		}
+		return expected[i].bits < expected[j].bits
+	})
+	entries := prefixTreeEntries(&tree)
+	if len(expected) == 0 {
+		tt.MustEqual(0, len(entries))
+	} else {
+		tt.MustEqual(expected, entries)
+	}
+}
@@ -0,0 +1,90 @@
+package num
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RawHex formats the 128-bit two's complement bit pattern of i in lowercase
+// hex, without a prefix. Unlike fmt's %x verb, which formats negative numbers
+// as a sign followed by the magnitude, RawHex(-1) is
+// "ffffffffffffffffffffffffffffffff".
+//
+// If pad is true, the result is zero padded to 32 digits.
+//
+// See I128FromRawHex for the counterpart.
+func (i I128) RawHex(pad bool) string {
+	return string(appendRaw(nil, i.hi, i.lo, 4, pad))
+}
+
+// RawBinary formats the 128-bit two's complement bit pattern of i in binary,
+// without a prefix. If pad is true, the result is zero padded to 128 digits.
+//
+// See I128FromRawBinary for the counterpart.
+func (i I128) RawBinary(pad bool) string {
+	return string(appendRaw(nil, i.hi, i.lo, 1, pad))
+}
+
+// I128FromRawHex parses a 128-bit two's complement bit pattern in hex, as
+// formatted by I128.RawHex. An optional "0x" or "0X" prefix is permitted.
+// Strings shorter than 32 digits are treated as if they were zero padded, so
+// "ff" is 255, not -1.
+//
+// If s contains more than 128 significant bits, err is a *NumError wrapping
+// ErrRange; if it is not valid hex, err wraps ErrSyntax.
+func I128FromRawHex(s string) (out I128, err error) {
+	return i128FromRaw("I128FromRawHex", s, "0x", 16)
+}
+
+// I128FromRawBinary parses a 128-bit two's complement bit pattern in binary,
+// as formatted by I128.RawBinary. An optional "0b" or "0B" prefix is
+// permitted. Strings shorter than 128 digits are treated as if they were zero
+// padded.
+//
+// If s contains more than 128 significant bits, err is a *NumError wrapping
+// ErrRange; if it is not valid binary, err wraps ErrSyntax.
+func I128FromRawBinary(s string) (out I128, err error) {
+	return i128FromRaw("I128FromRawBinary", s, "0b", 2)
+}
+
+func i128FromRaw(fn string, s string, prefix string, base uint64) (out I128, err error) {
+	digits := s
+	if len(digits) >= 2 && strings.EqualFold(digits[:2], prefix) {
+		digits = digits[2:]
+	}
+	u, inRange, ok := parseMagnitude(digits, base)
+	if !ok {
+		return out, syntaxError(fn, s)
+	} else if !inRange {
+		return out, rangeError(fn, s)
+	}
+	return u.AsI128(), nil
+}
+
+// appendRaw appends the 128 bits in hi and lo in the power-of-two base
+// 1<<shift, which must be either 1 (binary) or 4 (hex).
+func appendRaw(dst []byte, hi, lo uint64, shift uint, pad bool) []byte {
+	base := 1 << shift
+	width := 64 / int(shift)
+
+	if hi == 0 && !pad {
+		return strconv.AppendUint(dst, lo, base)
+	}
+
+	hiDigits := strconv.AppendUint(make([]byte, 0, width), hi, base)
+	if pad {
+		dst = appendZeros(dst, width-len(hiDigits))
+	}
+	dst = append(dst, hiDigits...)
+
+	loDigits := strconv.AppendUint(make([]byte, 0, width), lo, base)
+	dst = appendZeros(dst, width-len(loDigits))
+	return append(dst, loDigits...)
+}
+
+func appendZeros(dst []byte, n int) []byte {
+	for ; n > 0; n-- {
+		dst = append(dst, '0')
+	}
+	return dst
+}
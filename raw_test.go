@@ -0,0 +1,119 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestI128RawHex(t *testing.T) {
+	for idx, tc := range []struct {
+		in     I128
+		out    string
+		padded string
+	}{
+		{zeroI128, "0", strings.Repeat("0", 32)},
+		{i64(1), "1", strings.Repeat("0", 31) + "1"},
+		{i64(0xabc), "abc", strings.Repeat("0", 29) + "abc"},
+		{i64(-1), strings.Repeat("f", 32), strings.Repeat("f", 32)},
+		{i64(-2), strings.Repeat("f", 31) + "e", strings.Repeat("f", 31) + "e"},
+		{I128FromRaw(1, 0), "10000000000000000", "00000000000000010000000000000000"},
+		{I128FromRaw(1, 0xf), "1000000000000000f", "0000000000000001000000000000000f"},
+		{MaxI128, "7" + strings.Repeat("f", 31), "7" + strings.Repeat("f", 31)},
+		{MinI128, "8" + strings.Repeat("0", 31), "8" + strings.Repeat("0", 31)},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, tc.in.RawHex(false))
+			tt.MustEqual(tc.padded, tc.in.RawHex(true))
+
+			for _, s := range []string{tc.out, tc.padded, "0x" + tc.out, "0X" + strings.ToUpper(tc.padded)} {
+				v, err := I128FromRawHex(s)
+				tt.MustOK(err)
+				tt.MustEqual(tc.in, v)
+			}
+		})
+	}
+}
+
+func TestI128RawBinary(t *testing.T) {
+	for idx, tc := range []struct {
+		in     I128
+		out    string
+		padded string
+	}{
+		{zeroI128, "0", strings.Repeat("0", 128)},
+		{i64(5), "101", strings.Repeat("0", 125) + "101"},
+		{i64(-1), strings.Repeat("1", 128), strings.Repeat("1", 128)},
+		{I128FromRaw(1, 1), "1" + strings.Repeat("0", 63) + "1", strings.Repeat("0", 63) + "1" + strings.Repeat("0", 63) + "1"},
+		{MinI128, "1" + strings.Repeat("0", 127), "1" + strings.Repeat("0", 127)},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, tc.in.RawBinary(false))
+			tt.MustEqual(tc.padded, tc.in.RawBinary(true))
+
+			for _, s := range []string{tc.out, tc.padded, "0b" + tc.out} {
+				v, err := I128FromRawBinary(s)
+				tt.MustOK(err)
+				tt.MustEqual(tc.in, v)
+			}
+		})
+	}
+}
+
+func TestI128RawRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		v := randI128(scratch)
+		if i%2 == 0 {
+			v = v.Neg()
+		}
+		for _, pad := range []bool{true, false} {
+			h, err := I128FromRawHex(v.RawHex(pad))
+			tt.MustOK(err)
+			tt.MustEqual(v, h)
+
+			b, err := I128FromRawBinary(v.RawBinary(pad))
+			tt.MustOK(err)
+			tt.MustEqual(v, b)
+		}
+	}
+}
+
+func TestI128FromRawErrors(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		hex bool
+		err error
+	}{
+		{"", true, ErrSyntax},
+		{"0x", true, ErrSyntax},
+		{"-1", true, ErrSyntax},
+		{"fg", true, ErrSyntax},
+		{"1" + strings.Repeat("0", 32), true, ErrRange},
+		{"0" + strings.Repeat("f", 32), true, nil},
+		{"2", false, ErrSyntax},
+		{"0b", false, ErrSyntax},
+		{"1" + strings.Repeat("0", 128), false, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			var err error
+			if tc.hex {
+				_, err = I128FromRawHex(tc.in)
+			} else {
+				_, err = I128FromRawBinary(tc.in)
+			}
+			if tc.err == nil {
+				tt.MustOK(err)
+			} else {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			}
+		})
+	}
+}
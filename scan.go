@@ -0,0 +1,144 @@
+package num
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	binaryDigits      = "01"
+	octalDigits       = "01234567"
+	decimalDigitChars = "0123456789"
+	hexadecimalDigits = "0123456789aAbBcCdDeEfF"
+)
+
+// integerScanner reads an integer from a fmt.ScanState following the same
+// rules fmt uses when scanning into an int.
+type integerScanner struct {
+	state fmt.ScanState
+	buf   []byte
+}
+
+func (s *integerScanner) accept(ok string) bool {
+	r, _, err := s.state.ReadRune()
+	if err != nil {
+		return false
+	}
+	if strings.ContainsRune(ok, r) {
+		s.buf = append(s.buf, string(r)...)
+		return true
+	}
+	s.state.UnreadRune()
+	return false
+}
+
+// scanBasePrefix reports whether the integer begins with a "0b", "0o" or "0x"
+// base prefix and returns the base and digit string. Unlike fmt, a leading "0"
+// on its own does not select octal, so zero-padded decimals such as "007" scan
+// as decimal. Underscores are permitted between digits.
+func (s *integerScanner) scanBasePrefix() (base uint64, digits string, prefix bool) {
+	if !s.accept("0") {
+		return 10, decimalDigitChars + "_", false
+	}
+	switch {
+	case s.accept("bB"):
+		return 2, binaryDigits + "_", true
+	case s.accept("oO"):
+		return 8, octalDigits + "_", true
+	case s.accept("xX"):
+		return 16, hexadecimalDigits + "_", true
+	default:
+		// The "0" is not a prefix; scanInteger includes it in the digits:
+		return 10, decimalDigitChars + "_", false
+	}
+}
+
+// scanInteger scans an integer from state using the given verb, which may be
+// 'b', 'o', 'd', 'x', 'X' or 'v'. For 'v', the base is taken from an optional
+// "0b", "0o" or "0x" prefix, and underscores may separate digits. A
+// leading sign is accepted for any verb.
+//
+// The sign is returned separately from the magnitude, and tok contains the
+// scanned text for use in errors. If the magnitude overflows a U128, inRange
+// is false.
+func scanInteger(state fmt.ScanState, verb rune) (tok string, neg bool, mag U128, inRange bool, ok bool, err error) {
+	var base uint64
+	var digits string
+	switch verb {
+	case 'b':
+		base, digits = 2, binaryDigits
+	case 'o':
+		base, digits = 8, octalDigits
+	case 'd', 'v':
+		base, digits = 10, decimalDigitChars
+	case 'x', 'X':
+		base, digits = 16, hexadecimalDigits
+	default:
+		return "", false, mag, false, false, fmt.Errorf("num: bad verb '%%%c' for integer", verb)
+	}
+
+	state.SkipSpace()
+	if _, _, err := state.ReadRune(); err != nil {
+		return "", false, mag, false, false, io.EOF
+	}
+	state.UnreadRune()
+
+	s := &integerScanner{state: state}
+	if s.accept("+-") {
+		neg = s.buf[0] == '-'
+	}
+
+	prefix := false
+	start := len(s.buf)
+	if verb == 'v' {
+		base, digits, prefix = s.scanBasePrefix()
+		if prefix {
+			start = len(s.buf)
+		}
+	}
+	for s.accept(digits) {
+	}
+	tok = string(s.buf)
+
+	body := tok[start:]
+	if strings.IndexByte(body, '_') >= 0 {
+		// Underscores must separate digits, or a digit from the base prefix:
+		if strings.HasSuffix(body, "_") || strings.Contains(body, "__") || (!prefix && body[0] == '_') {
+			return tok, neg, mag, false, false, nil
+		}
+		body = strings.Replace(body, "_", "", -1)
+	}
+	mag, inRange, ok = parseMagnitude(body, base)
+	return tok, neg, mag, inRange, ok, nil
+}
+
+func (u *U128) scan(fn string, state fmt.ScanState, verb rune, clamp bool) error {
+	tok, neg, mag, inRange, ok, err := scanInteger(state, verb)
+	if err != nil {
+		return err
+	} else if !ok {
+		return syntaxError(fn, tok)
+	}
+	v, inRange := u128FromLiteral(neg, mag, inRange)
+	if !inRange && !clamp {
+		return rangeError(fn, tok)
+	}
+	*u = v
+	return nil
+}
+
+func (i *I128) scan(fn string, state fmt.ScanState, verb rune, clamp bool) error {
+	tok, neg, mag, inRange, ok, err := scanInteger(state, verb)
+	if err != nil {
+		return err
+	} else if !ok {
+		return syntaxError(fn, tok)
+	}
+	v, inRange := i128FromLiteral(neg, mag, inRange)
+	if !inRange && !clamp {
+		return rangeError(fn, tok)
+	}
+	*i = v
+	return nil
+}
@@ -0,0 +1,139 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128ScanVerbs(t *testing.T) {
+	for idx, tc := range []struct {
+		format string
+		in     string
+		out    U128
+		err    error
+	}{
+		{"%d", "123", u64(123), nil},
+		{"%d", "+123", u64(123), nil},
+		{"%d", "-0", zeroU128, nil},
+		{"%d", "-1", zeroU128, ErrRange},
+		{"%d", "1_000", u64(1), nil}, // underscores are only permitted with %v
+		{"%x", "ff", u64(0xff), nil},
+		{"%X", "FF", u64(0xff), nil},
+		{"%x", "ffffffffffffffffffffffffffffffff", MaxU128, nil},
+		{"%x", "100000000000000000000000000000000", zeroU128, ErrRange},
+		{"%x", "0x1f", zeroU128, nil}, // prefixes are only permitted with %v
+		{"%o", "777", u64(0777), nil},
+		{"%b", "101", u64(5), nil},
+		{"%b", "2", zeroU128, ErrSyntax},
+		{"%v", "123", u64(123), nil},
+		{"%v", "0x1f", u64(0x1f), nil},
+		{"%v", "0X1F", u64(0x1f), nil},
+		{"%v", "0b101", u64(5), nil},
+		{"%v", "0o17", u64(017), nil},
+		{"%v", "017", u64(17), nil}, // a bare leading zero does not select octal
+		{"%v", "000123", u64(123), nil},
+		{"%v", "0", zeroU128, nil},
+		{"%v", "08", u64(8), nil},
+		{"%v", "1_000", u64(1000), nil},
+		{"%v", "0x_ff_ff", u64(0xffff), nil},
+		{"%v", "0_7", u64(7), nil},
+		{"%v", "_1", zeroU128, ErrSyntax},
+		{"%v", "1__0", zeroU128, ErrSyntax},
+		{"%v", "1_", zeroU128, ErrSyntax},
+		{"%v", "0x", zeroU128, ErrSyntax},
+		{"%v", "0b", zeroU128, ErrSyntax},
+		{"%v", "+-1", zeroU128, ErrSyntax},
+		{"%v", "x", zeroU128, ErrSyntax},
+		{"%v", "0xffffffffffffffffffffffffffffffff", MaxU128, nil},
+		{"%v", "0x1_0000_0000_0000_0000_0000_0000_0000_0000", zeroU128, ErrRange},
+		{"%v", "340282366920938463463374607431768211455", MaxU128, nil},
+		{"%v", "340282366920938463463374607431768211456", zeroU128, ErrRange},
+		{"%4d", "123456", u64(1234), nil},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%s", idx, tc.format, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			var result U128
+			_, err := fmt.Sscanf(tc.in, tc.format, &result)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, result)
+		})
+	}
+}
+
+func TestI128ScanVerbs(t *testing.T) {
+	for idx, tc := range []struct {
+		format string
+		in     string
+		out    I128
+		err    error
+	}{
+		{"%d", "-123", i64(-123), nil},
+		{"%x", "-ff", i64(-0xff), nil},
+		{"%x", "-80000000000000000000000000000000", MinI128, nil},
+		{"%x", "-80000000000000000000000000000001", zeroI128, ErrRange},
+		{"%x", "80000000000000000000000000000000", zeroI128, ErrRange},
+		{"%x", "7fffffffffffffffffffffffffffffff", MaxI128, nil},
+		{"%b", "-101", i64(-5), nil},
+		{"%o", "+17", i64(017), nil},
+		{"%v", "-0x1f", i64(-0x1f), nil},
+		{"%v", "-0x_1f", i64(-0x1f), nil},
+		{"%v", "-0b1_01", i64(-5), nil},
+		{"%v", "-017", i64(-17), nil},
+		{"%v", "-1_000", i64(-1000), nil},
+		{"%v", "--1", zeroI128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%s", idx, tc.format, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			var result I128
+			_, err := fmt.Sscanf(tc.in, tc.format, &result)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, result)
+		})
+	}
+}
+
+func TestScanMultipleHex(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var a U128
+	var b I128
+	var c int
+	n, err := fmt.Sscanf("id=ffffffffffffffffffffffffffffffff off=-7f n=3", "id=%x off=%x n=%d", &a, &b, &c)
+	tt.MustOK(err)
+	tt.MustEqual(3, n)
+	tt.MustEqual(MaxU128, a)
+	tt.MustEqual(i64(-0x7f), b)
+	tt.MustEqual(3, c)
+}
+
+func TestScanBadVerb(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var u U128
+	_, err := fmt.Sscanf("1", "%s", &u)
+	tt.MustAssert(err != nil)
+	_, err = fmt.Sscanf("1", "%f", &u)
+	tt.MustAssert(err != nil)
+}
+
+func TestScanEOF(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var u U128
+	n, err := fmt.Sscan("", &u)
+	tt.MustEqual(0, n)
+	tt.MustAssert(err != nil)
+
+	var i I128
+	n, err = fmt.Sscan("   ", &i)
+	tt.MustEqual(0, n)
+	tt.MustAssert(err != nil)
+}
@@ -0,0 +1,178 @@
+package num
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// Value implements driver.Valuer. U128 is stored as a decimal string, which
+// is suitable for Postgres NUMERIC(39,0) and SQLite TEXT columns.
+func (u U128) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// Value implements driver.Valuer. I128 is stored as a decimal string, which
+// is suitable for Postgres NUMERIC(39,0) and SQLite TEXT columns.
+func (i I128) Value() (driver.Value, error) {
+	return i.String(), nil
+}
+
+// U128Scanner returns a sql.Scanner that stores the scanned value in dst.
+//
+// U128 can't implement sql.Scanner directly as its Scan method implements
+// fmt.Scanner, so this is required when passing a U128 to sql.Rows.Scan:
+//
+//	var id num.U128
+//	err := row.Scan(num.U128Scanner(&id))
+//
+// The scanner accepts a decimal string or []byte, a 16 byte big-endian
+// []byte (as long as it isn't also valid decimal text), or an int64. NULL is
+// an error; use NullU128 if the column is nullable.
+func U128Scanner(dst *U128) sql.Scanner {
+	return u128Scanner{dst}
+}
+
+type u128Scanner struct{ dst *U128 }
+
+func (s u128Scanner) Scan(src interface{}) error {
+	return s.dst.scanSQL("U128Scanner", src)
+}
+
+// I128Scanner returns a sql.Scanner that stores the scanned value in dst.
+//
+// I128 can't implement sql.Scanner directly as its Scan method implements
+// fmt.Scanner. See U128Scanner for details. 16 byte []byte values are
+// interpreted as big-endian two's complement.
+func I128Scanner(dst *I128) sql.Scanner {
+	return i128Scanner{dst}
+}
+
+type i128Scanner struct{ dst *I128 }
+
+func (s i128Scanner) Scan(src interface{}) error {
+	return s.dst.scanSQL("I128Scanner", src)
+}
+
+// NullU128 represents a U128 that may be NULL. It implements sql.Scanner and
+// driver.Valuer in the same way as sql.NullInt64.
+//
+// Note that as NullU128 is used for database scanning, its Scan method is
+// the sql.Scanner variant, not fmt.Scanner.
+type NullU128 struct {
+	U128  U128
+	Valid bool // Valid is true if U128 is not NULL
+}
+
+// Scan implements sql.Scanner. It accepts the same values as U128Scanner,
+// as well as NULL.
+func (n *NullU128) Scan(src interface{}) error {
+	if src == nil {
+		n.U128, n.Valid = zeroU128, false
+		return nil
+	}
+	if err := n.U128.scanSQL("NullU128.Scan", src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullU128) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.U128.Value()
+}
+
+// NullI128 represents an I128 that may be NULL. It implements sql.Scanner and
+// driver.Valuer in the same way as sql.NullInt64.
+type NullI128 struct {
+	I128  I128
+	Valid bool // Valid is true if I128 is not NULL
+}
+
+// Scan implements sql.Scanner. It accepts the same values as I128Scanner,
+// as well as NULL.
+func (n *NullI128) Scan(src interface{}) error {
+	if src == nil {
+		n.I128, n.Valid = zeroI128, false
+		return nil
+	}
+	if err := n.I128.scanSQL("NullI128.Scan", src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullI128) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.I128.Value()
+}
+
+func (u *U128) scanSQL(fn string, src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return fmt.Errorf("num: %s: cannot scan NULL into U128; use NullU128", fn)
+
+	case int64:
+		v, inRange := U128FromI64(src)
+		if !inRange {
+			return rangeError(fn, strconv.FormatInt(src, 10))
+		}
+		*u = v
+		return nil
+
+	case string:
+		return u.unmarshalText(fn, []byte(src), false)
+
+	case []byte:
+		if len(src) == 16 && !isSignedDecimal(string(src)) {
+			*u = MustU128FromBigEndian(src)
+			return nil
+		}
+		return u.unmarshalText(fn, src, false)
+
+	default:
+		return fmt.Errorf("num: %s: cannot scan %T into U128", fn, src)
+	}
+}
+
+func (i *I128) scanSQL(fn string, src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return fmt.Errorf("num: %s: cannot scan NULL into I128; use NullI128", fn)
+
+	case int64:
+		*i = I128From64(src)
+		return nil
+
+	case string:
+		return i.unmarshalText(fn, []byte(src), false)
+
+	case []byte:
+		if len(src) == 16 && !isSignedDecimal(string(src)) {
+			*i = MustI128FromBigEndian(src)
+			return nil
+		}
+		return i.unmarshalText(fn, src, false)
+
+	default:
+		return fmt.Errorf("num: %s: cannot scan %T into I128", fn, src)
+	}
+}
+
+func isSignedDecimal(s string) bool {
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	return isDecimalDigits(s)
+}
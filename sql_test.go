@@ -0,0 +1,195 @@
+package num
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+var (
+	_ driver.Valuer = U128{}
+	_ driver.Valuer = I128{}
+	_ driver.Valuer = NullU128{}
+	_ driver.Valuer = NullI128{}
+	_ sql.Scanner   = &NullU128{}
+	_ sql.Scanner   = &NullI128{}
+)
+
+// echoDriver is a minimal database/sql driver for testing. Every query
+// returns a single row whose columns are the query's arguments, after they
+// have been converted by database/sql.
+type echoDriver struct{}
+
+func (echoDriver) Open(name string) (driver.Conn, error) { return echoConn{}, nil }
+
+type echoConn struct{}
+
+func (echoConn) Prepare(query string) (driver.Stmt, error) { return echoStmt{}, nil }
+func (echoConn) Close() error                              { return nil }
+func (echoConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type echoStmt struct{}
+
+func (echoStmt) Close() error  { return nil }
+func (echoStmt) NumInput() int { return -1 }
+
+func (echoStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{row: args}, nil
+}
+
+type echoRows struct {
+	row  []driver.Value
+	done bool
+}
+
+func (r *echoRows) Columns() []string {
+	cols := make([]string, len(r.row))
+	for i := range cols {
+		cols[i] = fmt.Sprintf("c%d", i)
+	}
+	return cols
+}
+
+func (r *echoRows) Close() error { return nil }
+
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.row)
+	return nil
+}
+
+func init() {
+	sql.Register("num-echo", echoDriver{})
+}
+
+func openEchoDB(tt assert.T) *sql.DB {
+	db, err := sql.Open("num-echo", "")
+	tt.MustOK(err)
+	return db
+}
+
+func TestSQLValueRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	db := openEchoDB(tt)
+	defer db.Close()
+
+	for _, u := range []U128{zeroU128, u64(1), MaxU128} {
+		var out U128
+		tt.MustOK(db.QueryRow("echo", u).Scan(U128Scanner(&out)))
+		tt.MustEqual(u, out)
+	}
+
+	for _, i := range []I128{zeroI128, i64(-1), MinI128, MaxI128} {
+		var out I128
+		tt.MustOK(db.QueryRow("echo", i).Scan(I128Scanner(&out)))
+		tt.MustEqual(i, out)
+	}
+}
+
+func TestSQLNullRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	db := openEchoDB(tt)
+	defer db.Close()
+
+	var nu NullU128
+	var ni NullI128
+	tt.MustOK(db.QueryRow("echo", NullU128{U128: MaxU128, Valid: true}, NullI128{I128: MinI128, Valid: true}).Scan(&nu, &ni))
+	tt.MustEqual(NullU128{U128: MaxU128, Valid: true}, nu)
+	tt.MustEqual(NullI128{I128: MinI128, Valid: true}, ni)
+
+	tt.MustOK(db.QueryRow("echo", NullU128{}, NullI128{}).Scan(&nu, &ni))
+	tt.MustEqual(NullU128{}, nu)
+	tt.MustEqual(NullI128{}, ni)
+
+	var u U128
+	err := db.QueryRow("echo", nil).Scan(U128Scanner(&u))
+	tt.MustAssert(err != nil)
+}
+
+func TestU128ScanSQL(t *testing.T) {
+	for idx, tc := range []struct {
+		in  interface{}
+		out U128
+		err error
+	}{
+		{int64(0), zeroU128, nil},
+		{int64(1234), u64(1234), nil},
+		{int64(-1), zeroU128, ErrRange},
+		{"1234", u64(1234), nil},
+		{"340282366920938463463374607431768211455", MaxU128, nil},
+		{"340282366920938463463374607431768211456", zeroU128, ErrRange},
+		{"-1", zeroU128, ErrRange},
+		{"1.5", zeroU128, ErrSyntax},
+		{[]byte("1234"), u64(1234), nil},
+		{[]byte("1234567890123456"), u64(1234567890123456), nil},
+		{[]byte{0: 0x01, 15: 0x02}, U128FromRaw(0x0100000000000000, 2), nil},
+		{[]byte{0xff, 0xff}, zeroU128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%v", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			var result U128
+			err := U128Scanner(&result).Scan(tc.in)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, result)
+		})
+	}
+}
+
+func TestI128ScanSQL(t *testing.T) {
+	for idx, tc := range []struct {
+		in  interface{}
+		out I128
+		err error
+	}{
+		{int64(-1234), i64(-1234), nil},
+		{"-170141183460469231731687303715884105728", MinI128, nil},
+		{"-170141183460469231731687303715884105729", zeroI128, ErrRange},
+		{[]byte("-1234"), i64(-1234), nil},
+		{[]byte("-123456789012345"), i64(-123456789012345), nil},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe}, i64(-2), nil},
+		{[]byte("abc"), zeroI128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%v", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			var result I128
+			err := I128Scanner(&result).Scan(tc.in)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, result)
+		})
+	}
+}
+
+func TestScanSQLUnsupported(t *testing.T) {
+	for idx, in := range []interface{}{nil, 1.5, true, []byte{}} {
+		t.Run(fmt.Sprintf("%d/%T", idx, in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			u := u64(1)
+			tt.MustAssert(U128Scanner(&u).Scan(in) != nil)
+			tt.MustEqual(u64(1), u)
+
+			i := i64(1)
+			tt.MustAssert(I128Scanner(&i).Scan(in) != nil)
+			tt.MustEqual(i64(1), i)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package num
+
+import (
+	"fmt"
+	"sort"
+)
+
+// IPv6SubnetIterator iterates over the subnets of an IPv6Prefix in address
+// order. See IPv6Prefix.Subnets.
+type IPv6SubnetIterator struct {
+	next U128
+	last U128 // the first address of the final subnet
+	step U128
+	bits int
+	done bool
+}
+
+// Subnets returns an iterator over every subnet of p with a prefix length of
+// bits, in address order. A /48 has 65536 /64 subnets, for example. Ranges
+// at the 128-bit boundary are handled, so ::/0 can be iterated, although it
+// may take some time.
+//
+// bits must be between p.Bits and 128.
+func (p IPv6Prefix) Subnets(bits int) (*IPv6SubnetIterator, error) {
+	if !p.IsValid() || bits < p.Bits || bits > 128 {
+		return nil, fmt.Errorf("num: IPv6Prefix.Subnets: can't divide /%d into /%d subnets", p.Bits, bits)
+	}
+	return &IPv6SubnetIterator{
+		next: p.First(),
+		last: p.Last().And(IPv6Mask(bits)),
+		step: U128From64(1).Lsh(uint(128 - bits)),
+		bits: bits,
+	}, nil
+}
+
+// Next returns the next subnet. ok is false once every subnet has been
+// returned.
+func (it *IPv6SubnetIterator) Next() (p IPv6Prefix, ok bool) {
+	if it.done {
+		return p, false
+	}
+	p = IPv6Prefix{Addr: it.next, Bits: it.bits}
+	if it.next.Equal(it.last) {
+		it.done = true
+	} else {
+		it.next = it.next.Add(it.step)
+	}
+	return p, true
+}
+
+// maxIPv6Split is the largest number of subnets Split will return, to avoid
+// allocating huge slices. Use Subnets to iterate over more.
+const maxIPv6Split = 1 << 20
+
+// Split divides p into n equal subnets, which must be a power of two, and
+// returns them in address order. Splitting a /48 into 4 returns four /50s,
+// for example.
+//
+// n may be at most 1<<20; use Subnets to iterate over more subnets than that
+// without holding them all in memory.
+func (p IPv6Prefix) Split(n int) ([]IPv6Prefix, error) {
+	if n < 1 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("num: IPv6Prefix.Split: %d is not a power of two", n)
+	}
+	if n > maxIPv6Split {
+		return nil, fmt.Errorf("num: IPv6Prefix.Split: %d subnets is more than the maximum of %d; use Subnets", n, maxIPv6Split)
+	}
+	extra := 0
+	for 1<<uint(extra) < n {
+		extra++
+	}
+	it, err := p.Subnets(p.Bits + extra)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]IPv6Prefix, 0, n)
+	for sub, ok := it.Next(); ok; sub, ok = it.Next() {
+		out = append(out, sub)
+	}
+	return out, nil
+}
+
+// IPv6RangePrefixes returns the smallest list of prefixes that exactly cover
+// the addresses from start to end, inclusive, in address order. If start is
+// greater than end, the result is empty.
+func IPv6RangePrefixes(start, end U128) []IPv6Prefix {
+	return appendRangePrefixes(nil, start, end)
+}
+
+func appendRangePrefixes(dst []IPv6Prefix, start, end U128) []IPv6Prefix {
+	for !start.GreaterThan(end) {
+		// The largest block that starts at start is limited by its alignment,
+		// then by the end of the range:
+		hostBits := int(start.TrailingZeros())
+		for hostBits > 0 && start.Or(IPv6Mask(128-hostBits).Not()).GreaterThan(end) {
+			hostBits--
+		}
+		dst = append(dst, IPv6Prefix{Addr: start, Bits: 128 - hostBits})
+
+		last := start.Or(IPv6Mask(128 - hostBits).Not())
+		if last.Equal(MaxU128) {
+			break
+		}
+		start = last.Inc()
+	}
+	return dst
+}
+
+// MergeIPv6Prefixes returns the smallest list of prefixes, in address order,
+// that covers exactly the same addresses as prefixes. Overlapping and
+// adjacent prefixes are combined, and host bits are cleared.
+//
+// MergeIPv6Prefixes panics if any prefix is not valid.
+func MergeIPv6Prefixes(prefixes []IPv6Prefix) []IPv6Prefix {
+	if len(prefixes) == 0 {
+		return nil
+	}
+
+	type addrRange struct{ first, last U128 }
+	ranges := make([]addrRange, len(prefixes))
+	for i, p := range prefixes {
+		ranges[i] = addrRange{p.First(), p.Last()}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].first.LessThan(ranges[j].first)
+	})
+
+	var out []IPv6Prefix
+	cur := ranges[0]
+	for _, r := range ranges[1:] {
+		if cur.last.Equal(MaxU128) || !r.first.GreaterThan(cur.last.Inc()) {
+			if r.last.GreaterThan(cur.last) {
+				cur.last = r.last
+			}
+			continue
+		}
+		out = appendRangePrefixes(out, cur.first, cur.last)
+		cur = r
+	}
+	return appendRangePrefixes(out, cur.first, cur.last)
+}
@@ -0,0 +1,179 @@
+package num
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func mustParseIPv6Prefix(tt assert.T, s string) IPv6Prefix {
+	p, err := ParseIPv6Prefix(s)
+	tt.MustOK(err)
+	return p
+}
+
+func prefixStrings(ps []IPv6Prefix) []string {
+	out := make([]string, len(ps))
+	for i, p := range ps {
+		out[i] = p.String()
+	}
+	return out
+}
+
+func TestIPv6PrefixSplit(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		n   int
+		out []string
+	}{
+		{"2001:db8::/32", 1, []string{"2001:db8::/32"}},
+		{"2001:db8::/32", 2, []string{"2001:db8::/33", "2001:db8:8000::/33"}},
+		{"2001:db8::1/32", 4, []string{"2001:db8::/34", "2001:db8:4000::/34", "2001:db8:8000::/34", "2001:db8:c000::/34"}},
+		{"::/0", 2, []string{"::/1", "8000::/1"}},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/126", 4, []string{
+			"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffc/128",
+			"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd/128",
+			"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/128",
+			"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128",
+		}},
+	} {
+		t.Run(fmt.Sprintf("%d/%s/%d", idx, tc.in, tc.n), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			out, err := mustParseIPv6Prefix(tt, tc.in).Split(tc.n)
+			tt.MustOK(err)
+			tt.MustEqual(tc.out, prefixStrings(out))
+		})
+	}
+
+	tt := assert.WrapTB(t)
+	p := mustParseIPv6Prefix(tt, "2001:db8::/127")
+	for _, n := range []int{0, -1, 3, 6, 4} {
+		_, err := p.Split(n)
+		tt.MustAssert(err != nil, "%d", n)
+	}
+
+	// Too many subnets to return in a slice must fail rather than panic or
+	// exhaust memory, even when they fit in the address space:
+	p = mustParseIPv6Prefix(tt, "::/0")
+	maxPow2 := int(^uint(0)>>2) + 1 // 1<<62 on 64-bit platforms
+	for _, n := range []int{maxIPv6Split * 2, 1 << 30, maxPow2} {
+		_, err := p.Split(n)
+		tt.MustAssert(err != nil, "%d", n)
+	}
+	out, err := p.Split(maxIPv6Split)
+	tt.MustOK(err)
+	tt.MustEqual(maxIPv6Split, len(out))
+	tt.MustEqual("ffff:f000::/20", out[len(out)-1].String())
+}
+
+func TestIPv6PrefixSubnets(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	it, err := mustParseIPv6Prefix(tt, "2001:db8::/48").Subnets(64)
+	tt.MustOK(err)
+	var n int
+	var first, last IPv6Prefix
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		if n == 0 {
+			first = p
+		}
+		last = p
+		n++
+	}
+	tt.MustEqual(65536, n)
+	tt.MustEqual("2001:db8::/64", first.String())
+	tt.MustEqual("2001:db8:0:ffff::/64", last.String())
+
+	_, ok := it.Next()
+	tt.MustAssert(!ok)
+
+	// Iterating ::/0 in /1s must stop at the 128-bit boundary rather than
+	// wrapping around:
+	it, err = mustParseIPv6Prefix(tt, "::/0").Subnets(1)
+	tt.MustOK(err)
+	var all []IPv6Prefix
+	for p, ok := it.Next(); ok; p, ok = it.Next() {
+		all = append(all, p)
+	}
+	tt.MustEqual([]string{"::/1", "8000::/1"}, prefixStrings(all))
+
+	for _, bits := range []int{47, 129} {
+		_, err = mustParseIPv6Prefix(tt, "2001:db8::/48").Subnets(bits)
+		tt.MustAssert(err != nil)
+	}
+}
+
+func TestIPv6RangePrefixes(t *testing.T) {
+	for idx, tc := range []struct {
+		start, end string
+		out        []string
+	}{
+		{"2001:db8::", "2001:db8::", []string{"2001:db8::/128"}},
+		{"2001:db8::", "2001:db8::ff", []string{"2001:db8::/120"}},
+		{"2001:db8::1", "2001:db8::6", []string{"2001:db8::1/128", "2001:db8::2/127", "2001:db8::4/127", "2001:db8::6/128"}},
+		{"::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"::/0"}},
+		{"::1", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", nil},
+		{"8000::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"8000::/1"}},
+		{"::", "7fff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"::/1"}},
+		{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"}},
+		{"::2", "::1", []string{}},
+	} {
+		t.Run(fmt.Sprintf("%d/%s-%s", idx, tc.start, tc.end), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			start := mustParseIPv6Prefix(tt, tc.start+"/128").Addr
+			end := mustParseIPv6Prefix(tt, tc.end+"/128").Addr
+			out := IPv6RangePrefixes(start, end)
+
+			if tc.out != nil {
+				tt.MustEqual(tc.out, prefixStrings(out))
+			} else {
+				// ::1 to the end needs one prefix for every bit:
+				tt.MustEqual(128, len(out))
+				tt.MustEqual("::1/128", out[0].String())
+				tt.MustEqual("8000::/1", out[127].String())
+			}
+
+			// The prefixes must be contiguous and cover exactly the range:
+			if len(out) > 0 {
+				tt.MustEqual(start, out[0].First())
+				tt.MustEqual(end, out[len(out)-1].Last())
+				for i := 1; i < len(out); i++ {
+					tt.MustEqual(out[i-1].Last().Inc(), out[i].First())
+				}
+			}
+		})
+	}
+}
+
+func TestMergeIPv6Prefixes(t *testing.T) {
+	for idx, tc := range []struct {
+		in  []string
+		out []string
+	}{
+		{nil, nil},
+		{[]string{"2001:db8::/64"}, []string{"2001:db8::/64"}},
+		{[]string{"2001:db8::1/64"}, []string{"2001:db8::/64"}},
+		{[]string{"2001:db8:0:1::/64", "2001:db8::/64"}, []string{"2001:db8::/63"}},
+		{[]string{"2001:db8::/64", "2001:db8::/48", "2001:db8:1::/48"}, []string{"2001:db8::/47"}},
+		{[]string{"2001:db8::/64", "2001:db8:0:2::/64"}, []string{"2001:db8::/64", "2001:db8:0:2::/64"}},
+		{[]string{"2001:db8:0:1::/64", "2001:db8:0:2::/64"}, []string{"2001:db8:0:1::/64", "2001:db8:0:2::/64"}},
+		{[]string{"8000::/1", "::/1"}, []string{"::/0"}},
+		{[]string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128", "::/0", "::/1"}, []string{"::/0"}},
+		{[]string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/128", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128"}, []string{"ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffe/127"}},
+	} {
+		t.Run(fmt.Sprintf("%d", idx), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			var in []IPv6Prefix
+			for _, s := range tc.in {
+				in = append(in, mustParseIPv6Prefix(tt, s))
+			}
+			out := MergeIPv6Prefixes(in)
+			if tc.out == nil {
+				tt.MustEqual(0, len(out))
+			} else {
+				tt.MustEqual(tc.out, prefixStrings(out))
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package num
 
 import (
 	"fmt"
+	"io"
 	"math/big"
 	"math/bits"
 	"strconv"
@@ -33,31 +34,41 @@ func U128FromI64(v int64) (out U128, inRange bool) {
 func MustU128FromI64(v int64) (out U128) {
 	out, inRange := U128FromI64(v)
 	if !inRange {
-		panic(fmt.Errorf("num: int64 %d was not in valid U128 range", v))
+		panic(rangeError("MustU128FromI64", strconv.FormatInt(v, 10)))
 	}
 	return out
 }
 
 // U128FromString creates a U128 from a string. Overflow truncates to MaxU128
 // and sets inRange to 'false'. Only decimal strings are currently supported.
+//
+// If s is not a valid decimal string, err is a *NumError wrapping ErrSyntax.
 func U128FromString(s string) (out U128, inRange bool, err error) {
+	out, inRange, ok := u128FromString(s)
+	if !ok {
+		return out, false, syntaxError("U128FromString", s)
+	}
+	return out, inRange, nil
+}
+
+func u128FromString(s string) (out U128, inRange bool, ok bool) {
 	// This deliberately limits the scope of what we accept as input just in case
 	// we decide to hand-roll our own fast decimal-only parser:
 	b, ok := new(big.Int).SetString(s, 10)
 	if !ok {
-		return out, false, fmt.Errorf("num: u128 string %q invalid", s)
+		return out, false, false
 	}
 	out, inRange = U128FromBigInt(b)
-	return out, inRange, nil
+	return out, inRange, true
 }
 
 func MustU128FromString(s string) U128 {
-	out, inRange, err := U128FromString(s)
-	if err != nil {
-		panic(err)
+	out, inRange, ok := u128FromString(s)
+	if !ok {
+		panic(syntaxError("MustU128FromString", s))
 	}
 	if !inRange {
-		panic(fmt.Errorf("num: string %q was not in valid U128 range", s))
+		panic(rangeError("MustU128FromString", s))
 	}
 	return out
 }
@@ -113,7 +124,7 @@ func U128FromBigInt(v *big.Int) (out U128, inRange bool) {
 func MustU128FromBigInt(b *big.Int) U128 {
 	out, inRange := U128FromBigInt(b)
 	if !inRange {
-		panic(fmt.Errorf("num: big.Int %d was not in valid U128 range", b))
+		panic(rangeError("MustU128FromBigInt", b.String()))
 	}
 	return out
 }
@@ -125,7 +136,7 @@ func U128FromFloat32(f float32) (out U128, inRange bool) {
 func MustU128FromFloat32(f float32) U128 {
 	out, inRange := U128FromFloat32(f)
 	if !inRange {
-		panic(fmt.Errorf("num: float32 %f was not in valid U128 range", f))
+		panic(rangeError("MustU128FromFloat32", strconv.FormatFloat(float64(f), 'g', -1, 32)))
 	}
 	return out
 }
@@ -166,7 +177,7 @@ func U128FromFloat64(f float64) (out U128, inRange bool) {
 func MustU128FromFloat64(f float64) U128 {
 	out, inRange := U128FromFloat64(f)
 	if !inRange {
-		panic(fmt.Errorf("num: float64 %f was not in valid U128 range", f))
+		panic(rangeError("MustU128FromFloat64", strconv.FormatFloat(f, 'g', -1, 64)))
 	}
 	return out
 }
@@ -194,27 +205,47 @@ func (u U128) String() string {
 	return v.String()
 }
 
-func (u U128) Format(s fmt.State, c rune) {
-	// FIXME: This is good enough for now, but not forever.
-	u.AsBigInt().Format(s, c)
-}
-
-func (u *U128) Scan(state fmt.ScanState, verb rune) error {
-	t, err := state.Token(true, nil)
-	if err != nil {
-		return err
+// GoString implements fmt.GoStringer, and is used for the %#v verb. The
+// result is a valid Go expression that creates u, i.e.
+// "num.U128From64(1)" or "num.U128FromRaw(0x1, 0x0)".
+func (u U128) GoString() string {
+	if u.hi == 0 {
+		return "num.U128From64(" + strconv.FormatUint(u.lo, 10) + ")"
 	}
-	ts := string(t)
+	return "num.U128FromRaw(0x" + strconv.FormatUint(u.hi, 16) + ", 0x" + strconv.FormatUint(u.lo, 16) + ")"
+}
 
-	v, inRange, err := U128FromString(ts)
-	if err != nil {
-		return err
-	} else if !inRange {
-		return fmt.Errorf("num: u128 value %q is not in range", ts)
+// Format implements fmt.Formatter. In addition to the integer verbs supported
+// by big.Int, the float verbs 'e', 'E', 'f', 'F', 'g' and 'G' are supported,
+// and are rounded exactly at the requested precision:
+//
+//	fmt.Printf("%.4e", MaxU128) // 3.4028e+38
+func (u U128) Format(s fmt.State, c rune) {
+	switch c {
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		formatFloat(s, c, false, u)
+	case 'v':
+		if s.Flag('#') {
+			io.WriteString(s, u.GoString())
+			return
+		}
+		fallthrough
+	default:
+		// FIXME: This is good enough for now, but not forever.
+		u.AsBigInt().Format(s, c)
 	}
-	*u = v
+}
 
-	return nil
+// Scan implements fmt.Scanner. It supports the verbs %b, %o, %d, %x, %X
+// and %v, following the same rules as fmt does when scanning an int: a sign
+// is accepted, and for %v the base is taken from a "0b", "0o" or "0x" prefix
+// and underscores may separate digits. Unlike fmt, a leading "0" on its own
+// does not select octal, so "0123" scans as 123.
+//
+// Values outside the range of a U128 are rejected with ErrRange; see
+// U128Clamped if you would prefer to clamp them.
+func (u *U128) Scan(state fmt.ScanState, verb rune) error {
+	return u.scan("U128.Scan", state, verb, false)
 }
 
 func (u U128) IntoBigInt(b *big.Int) {
@@ -987,10 +1018,19 @@ func (u U128) MarshalText() ([]byte, error) {
 	return []byte(u.String()), nil
 }
 
+// UnmarshalText implements encoding.TextUnmarshaler. Values outside the range
+// of a U128 are rejected with ErrRange; see U128Clamped if you would prefer to
+// clamp them.
 func (u *U128) UnmarshalText(bts []byte) (err error) {
-	v, _, err := U128FromString(string(bts))
-	if err != nil {
-		return err
+	return u.unmarshalText("U128.UnmarshalText", bts, false)
+}
+
+func (u *U128) unmarshalText(fn string, bts []byte, clamp bool) (err error) {
+	v, inRange, ok := u128FromString(string(bts))
+	if !ok {
+		return syntaxError(fn, string(bts))
+	} else if !inRange && !clamp {
+		return rangeError(fn, string(bts))
 	}
 	*u = v
 	return nil
@@ -1000,21 +1040,15 @@ func (u U128) MarshalJSON() ([]byte, error) {
 	return []byte(`"` + u.String() + `"`), nil
 }
 
+// UnmarshalJSON implements json.Unmarshaler. It accepts a JSON number or
+// string containing a decimal integer, a "0x"-prefixed hex integer, or a
+// decimal with a fraction or exponent if its value is an exact integer (i.e.
+// 1e20 or 1.5e1). JSON null leaves the value unchanged.
+//
+// Values outside the range of a U128 are rejected with ErrRange; see
+// U128Clamped if you would prefer to clamp them.
 func (u *U128) UnmarshalJSON(bts []byte) (err error) {
-	if bts[0] == '"' {
-		ln := len(bts)
-		if bts[ln-1] != '"' {
-			return fmt.Errorf("num: u128 invalid JSON %q", string(bts))
-		}
-		bts = bts[1 : ln-1]
-	}
-
-	v, _, err := U128FromString(string(bts))
-	if err != nil {
-		return err
-	}
-	*u = v
-	return nil
+	return u.unmarshalJSON("U128.UnmarshalJSON", bts, false)
 }
 
 // Put big-endian encoded bytes representing this U128 into byte slice b.
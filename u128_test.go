@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"go/parser"
 	"math"
 	"math/big"
 	"math/rand"
@@ -259,6 +260,29 @@ func TestU128Format(t *testing.T) {
 	}
 }
 
+func TestU128GoString(t *testing.T) {
+	for idx, tc := range []struct {
+		v   U128
+		out string
+	}{
+		{zeroU128, "num.U128From64(0)"},
+		{u64(1234), "num.U128From64(1234)"},
+		{u64(maxUint64), "num.U128From64(18446744073709551615)"},
+		{U128FromRaw(1, 0), "num.U128FromRaw(0x1, 0x0)"},
+		{MaxU128, "num.U128FromRaw(0xffffffffffffffff, 0xffffffffffffffff)"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.v), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			tt.MustEqual(tc.out, tc.v.GoString())
+			tt.MustEqual(tc.out, fmt.Sprintf("%#v", tc.v))
+			tt.MustEqual("[]num.U128{"+tc.out+"}", fmt.Sprintf("%#v", []U128{tc.v}))
+
+			_, err := parser.ParseExpr(tc.out)
+			tt.MustOK(err)
+		})
+	}
+}
+
 func TestU128FromBigInt(t *testing.T) {
 	for idx, tc := range []struct {
 		a   *big.Int
@@ -675,7 +699,7 @@ func TestU128Scan(t *testing.T) {
 		ok  bool
 	}{
 		{"1", u64(1), true},
-		{"0xFF", zeroU128, false},
+		{"0xFF", u64(0xFF), true},
 		{"-1", zeroU128, false},
 		{"340282366920938463463374607431768211456", zeroU128, false},
 	} {
@@ -0,0 +1,94 @@
+package num
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrULIDOverflow is returned by ULIDGenerator.Next if the random component
+// of a ULID can't be incremented any further within the same millisecond.
+var ErrULIDOverflow = errors.New("num: ULID random component overflow")
+
+const (
+	ulidRandomBits = 80
+	ulidRandomMask = 1<<(ulidRandomBits-64) - 1 // mask for the hi 64 bits
+)
+
+// RandULID generates a ULID with the 48-bit Unix millisecond timestamp of t
+// in the high bits, followed by 80 random bits from source.
+//
+// ULIDs generated by RandULID within the same millisecond are not ordered;
+// use ULIDGenerator if that is required.
+func RandULID(source RandSource, t time.Time) U128 {
+	u := RandU128(source)
+	u.hi = unixMilli48(t)<<16 | u.hi&ulidRandomMask
+	return u
+}
+
+// U128FromULID parses the 26 character Crockford base32 form of a ULID, as
+// formatted by U128.ULIDString. Decoding is case-insensitive.
+//
+// If s is not a valid ULID, err is a *NumError wrapping ErrSyntax. If the
+// first character is greater than '7', err wraps ErrRange.
+func U128FromULID(s string) (out U128, err error) {
+	if len(s) != crockfordWidth {
+		return out, syntaxError("U128FromULID", s)
+	}
+	out, inRange, ok := parseBase([]byte(s), 32, &crockfordDecode)
+	if !ok {
+		return zeroU128, syntaxError("U128FromULID", s)
+	} else if !inRange {
+		return zeroU128, rangeError("U128FromULID", s)
+	}
+	return out, nil
+}
+
+// ULIDString formats u as a ULID, which is 26 characters of Crockford base32.
+func (u U128) ULIDString() string {
+	return u.Base32Crockford(false)
+}
+
+// ULIDTime returns the timestamp component of u, interpreted as a ULID.
+func (u U128) ULIDTime() time.Time {
+	ms := int64(u.hi >> 16)
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
+}
+
+// ULIDGenerator generates monotonically increasing ULIDs. Within the same
+// millisecond, each ULID is the previous one plus one. Once the millisecond
+// changes, the random component is generated afresh.
+//
+// If the clock moves backwards, the previous timestamp continues to be used
+// until the clock catches up, so ULIDs are always strictly increasing.
+//
+// ULIDGenerator is not safe for concurrent use.
+type ULIDGenerator struct {
+	source RandSource
+	last   U128
+	lastMS uint64
+	init   bool
+}
+
+// NewULIDGenerator creates a ULIDGenerator that takes random bits from
+// source.
+func NewULIDGenerator(source RandSource) *ULIDGenerator {
+	return &ULIDGenerator{source: source}
+}
+
+// Next returns a ULID for t that is greater than the previous one. If the
+// random component of the previous ULID can't be incremented within the same
+// millisecond, Next returns ErrULIDOverflow.
+func (g *ULIDGenerator) Next(t time.Time) (U128, error) {
+	ms := unixMilli48(t)
+	if !g.init || ms > g.lastMS {
+		g.last, g.lastMS, g.init = RandULID(g.source, t), ms, true
+		return g.last, nil
+	}
+
+	next := g.last.Inc()
+	if next.hi>>16 != g.lastMS {
+		return zeroU128, ErrULIDOverflow
+	}
+	g.last = next
+	return next, nil
+}
@@ -0,0 +1,110 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128FromULID(t *testing.T) {
+	for idx, tc := range []struct {
+		in  string
+		out U128
+		err error
+	}{
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAV", u128s("0x01563e3ab5d3d6764c61efb99302bd5b"), nil},
+		{"01arz3ndektsv4rrffq69g5fav", u128s("0x01563e3ab5d3d6764c61efb99302bd5b"), nil},
+		{"00000000000000000000000000", zeroU128, nil},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", MaxU128, nil},
+		{"80000000000000000000000000", zeroU128, ErrRange},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA", zeroU128, ErrSyntax},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAVX", zeroU128, ErrSyntax},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", zeroU128, ErrSyntax},
+		{"01ARZ3NDEK-SV4RRFFQ69G5FAV", zeroU128, ErrSyntax},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			v, err := U128FromULID(tc.in)
+			if tc.err != nil {
+				tt.MustAssert(errors.Is(err, tc.err), "%v", err)
+			} else {
+				tt.MustOK(err)
+			}
+			tt.MustEqual(tc.out, v)
+		})
+	}
+}
+
+func TestULIDTime(t *testing.T) {
+	tt := assert.WrapTB(t)
+	u, err := U128FromULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	tt.MustOK(err)
+	tt.MustEqual("01ARZ3NDEKTSV4RRFFQ69G5FAV", u.ULIDString())
+
+	ts := u.ULIDTime()
+	tt.MustEqual(int64(1469922850259), ts.UnixNano()/int64(time.Millisecond))
+	tt.MustAssert(ts.Equal(time.Date(2016, 7, 30, 23, 54, 10, 259000000, time.UTC)))
+}
+
+func TestRandULID(t *testing.T) {
+	tt := assert.WrapTB(t)
+	ts := time.Date(2016, 7, 30, 23, 54, 10, 259999999, time.UTC)
+
+	u := RandULID(fixedRandSource(0), ts)
+	tt.MustEqual("01ARZ3NDEK0000000000000000", u.ULIDString())
+	tt.MustAssert(u.ULIDTime().Equal(ts.Truncate(time.Millisecond)))
+
+	u = RandULID(fixedRandSource(maxUint64), ts)
+	tt.MustEqual("01ARZ3NDEKZZZZZZZZZZZZZZZZ", u.ULIDString())
+}
+
+func TestULIDGenerator(t *testing.T) {
+	tt := assert.WrapTB(t)
+	ts := time.Date(2016, 7, 30, 23, 54, 10, 259000000, time.UTC)
+	gen := NewULIDGenerator(globalRNG)
+
+	first, err := gen.Next(ts)
+	tt.MustOK(err)
+	tt.MustAssert(first.ULIDTime().Equal(ts))
+
+	// Same millisecond increments:
+	prev := first
+	for i := 1; i < 100; i++ {
+		next, err := gen.Next(ts.Add(time.Duration(i) * time.Microsecond))
+		tt.MustOK(err)
+		tt.MustEqual(prev.Inc(), next)
+		prev = next
+	}
+
+	// Clock going backwards keeps the last timestamp:
+	next, err := gen.Next(ts.Add(-time.Second))
+	tt.MustOK(err)
+	tt.MustEqual(prev.Inc(), next)
+	prev = next
+
+	// Later millisecond takes a fresh random component:
+	later, err := gen.Next(ts.Add(time.Millisecond))
+	tt.MustOK(err)
+	tt.MustAssert(later.GreaterThan(prev))
+	tt.MustAssert(later.ULIDTime().Equal(ts.Add(time.Millisecond)))
+}
+
+func TestULIDGeneratorOverflow(t *testing.T) {
+	tt := assert.WrapTB(t)
+	ts := time.Date(2016, 7, 30, 23, 54, 10, 259000000, time.UTC)
+	gen := NewULIDGenerator(fixedRandSource(maxUint64))
+
+	u, err := gen.Next(ts)
+	tt.MustOK(err)
+	tt.MustEqual("01ARZ3NDEKZZZZZZZZZZZZZZZZ", u.ULIDString())
+
+	_, err = gen.Next(ts)
+	tt.MustEqual(ErrULIDOverflow, err)
+
+	// The next millisecond succeeds again:
+	_, err = gen.Next(ts.Add(time.Millisecond))
+	tt.MustOK(err)
+}
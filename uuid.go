@@ -0,0 +1,125 @@
+package num
+
+import (
+	"strings"
+	"time"
+)
+
+// UUID variants, as returned by U128.UUIDVariant.
+const (
+	UUIDVariantNCS       = 0 // Reserved for NCS backward compatibility (0b0xx)
+	UUIDVariantRFC9562   = 2 // The variant used by RFC 9562 (and RFC 4122) (0b10x)
+	UUIDVariantMicrosoft = 6 // Reserved for Microsoft backward compatibility (0b110)
+	UUIDVariantFuture    = 7 // Reserved for future definition (0b111)
+)
+
+const (
+	uuidVersionMask = 0xf << 12
+	uuidVariantMask = 0x3 << 62
+	uuidVariantRFC  = 0x2 << 62
+)
+
+// U128FromUUID parses a UUID as a big-endian U128. The canonical
+// 8-4-4-4-12 form is accepted, as are the same digits surrounded by braces
+// or prefixed with "urn:uuid:". Hex digits may be in either case.
+//
+//	f81d4fae-7dec-11d0-a765-00a0c91e6bf6
+//	{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}
+//	urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6
+//
+// If s is not a valid UUID, err is a *NumError wrapping ErrSyntax.
+func U128FromUUID(s string) (out U128, err error) {
+	v := s
+	switch {
+	case len(v) == 38 && v[0] == '{' && v[37] == '}':
+		v = v[1:37]
+	case len(v) == 45 && strings.EqualFold(v[:9], "urn:uuid:"):
+		v = v[9:]
+	}
+	if len(v) != 36 || v[8] != '-' || v[13] != '-' || v[18] != '-' || v[23] != '-' {
+		return out, syntaxError("U128FromUUID", s)
+	}
+
+	for i := 0; i < 36; i++ {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			continue
+		}
+		d := digitVal(v[i])
+		if d >= 16 {
+			return zeroU128, syntaxError("U128FromUUID", s)
+		}
+		out.hi = out.hi<<4 | out.lo>>60
+		out.lo = out.lo<<4 | d
+	}
+	return out, nil
+}
+
+// UUIDString formats u as a UUID in the canonical lowercase 8-4-4-4-12 form,
+// i.e. "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func (u U128) UUIDString() string {
+	var scratch [32]byte
+	hex := appendRaw(scratch[:0], u.hi, u.lo, 4, true)
+
+	var buf [36]byte
+	copy(buf[0:8], hex[0:8])
+	buf[8] = '-'
+	copy(buf[9:13], hex[8:12])
+	buf[13] = '-'
+	copy(buf[14:18], hex[12:16])
+	buf[18] = '-'
+	copy(buf[19:23], hex[16:20])
+	buf[23] = '-'
+	copy(buf[24:], hex[20:32])
+	return string(buf[:])
+}
+
+// UUIDVersion returns the version field of u, interpreted as a UUID. This is
+// only meaningful if UUIDVariant is UUIDVariantRFC9562.
+func (u U128) UUIDVersion() int {
+	return int((u.hi & uuidVersionMask) >> 12)
+}
+
+// UUIDVariant returns the variant field of u, interpreted as a UUID, which
+// is one of the UUIDVariant constants.
+func (u U128) UUIDVariant() int {
+	switch {
+	case u.lo>>63 == 0:
+		return UUIDVariantNCS
+	case u.lo>>62 == 0x2:
+		return UUIDVariantRFC9562
+	case u.lo>>61 == 0x6:
+		return UUIDVariantMicrosoft
+	default:
+		return UUIDVariantFuture
+	}
+}
+
+// RandUUIDv4 generates a random version 4 UUID, as described in RFC 9562,
+// from source. source should be cryptographically secure if the UUID must be
+// unguessable.
+func RandUUIDv4(source RandSource) U128 {
+	u := RandU128(source)
+	u.hi = u.hi&^uuidVersionMask | 4<<12
+	u.lo = u.lo&^uuidVariantMask | uuidVariantRFC
+	return u
+}
+
+// RandUUIDv7 generates a time-ordered version 7 UUID, as described in RFC
+// 9562, with the 48-bit Unix millisecond timestamp of t followed by random
+// bits from source.
+//
+// Times before the Unix epoch, or after the year 10889, wrap around.
+// RandUUIDv7 does not guarantee monotonicity for UUIDs generated in the same
+// millisecond.
+func RandUUIDv7(source RandSource, t time.Time) U128 {
+	ms := unixMilli48(t)
+	u := RandU128(source)
+	u.hi = ms<<16 | 7<<12 | u.hi&0xfff
+	u.lo = u.lo&^uuidVariantMask | uuidVariantRFC
+	return u
+}
+
+// unixMilli48 returns the low 48 bits of the Unix millisecond timestamp of t.
+func unixMilli48(t time.Time) uint64 {
+	return uint64(t.Unix()*1000+int64(t.Nanosecond())/1e6) & (1<<48 - 1)
+}
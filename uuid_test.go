@@ -0,0 +1,133 @@
+package num
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128FromUUID(t *testing.T) {
+	expected := u128s("0xf81d4fae7dec11d0a76500a0c91e6bf6")
+
+	for idx, tc := range []struct {
+		in  string
+		out U128
+		ok  bool
+	}{
+		{"f81d4fae-7dec-11d0-a765-00a0c91e6bf6", expected, true},
+		{"F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6", expected, true},
+		{"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}", expected, true},
+		{"urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", expected, true},
+		{"URN:UUID:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", expected, true},
+		{"00000000-0000-0000-0000-000000000000", zeroU128, true},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", MaxU128, true},
+		{"", zeroU128, false},
+		{"f81d4fae7dec11d0a76500a0c91e6bf6", zeroU128, false},
+		{"f81d4fae-7dec-11d0-a765-00a0c91e6bf", zeroU128, false},
+		{"f81d4fae-7dec-11d0-a765-00a0c91e6bf6a", zeroU128, false},
+		{"f81d4fae-7dec-11d0a-765-00a0c91e6bf6", zeroU128, false},
+		{"g81d4fae-7dec-11d0-a765-00a0c91e6bf6", zeroU128, false},
+		{"f81d4fae-7dec-11d0-a765-00a0c91e6bfz", zeroU128, false},
+		{"+81d4fae-7dec-11d0-a765-00a0c91e6bf6", zeroU128, false},
+		{"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6", zeroU128, false},
+		{"(f81d4fae-7dec-11d0-a765-00a0c91e6bf6)", zeroU128, false},
+		{"urn:uid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", zeroU128, false},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			v, err := U128FromUUID(tc.in)
+			if tc.ok {
+				tt.MustOK(err)
+			} else {
+				tt.MustAssert(errors.Is(err, ErrSyntax), "%v", err)
+			}
+			tt.MustEqual(tc.out, v)
+		})
+	}
+}
+
+func TestU128UUIDString(t *testing.T) {
+	tt := assert.WrapTB(t)
+	tt.MustEqual("f81d4fae-7dec-11d0-a765-00a0c91e6bf6", u128s("0xf81d4fae7dec11d0a76500a0c91e6bf6").UUIDString())
+	tt.MustEqual("00000000-0000-0000-0000-000000000000", zeroU128.UUIDString())
+	tt.MustEqual("00000000-0000-0000-0000-000000000001", u64(1).UUIDString())
+	tt.MustEqual("ffffffff-ffff-ffff-ffff-ffffffffffff", MaxU128.UUIDString())
+
+	scratch := make([]byte, 16)
+	for i := 0; i < 1000; i++ {
+		u := randU128(scratch)
+		v, err := U128FromUUID(u.UUIDString())
+		tt.MustOK(err)
+		tt.MustEqual(u, v)
+	}
+}
+
+func TestUUIDVersionVariant(t *testing.T) {
+	for idx, tc := range []struct {
+		in      string
+		version int
+		variant int
+	}{
+		{"f81d4fae-7dec-11d0-a765-00a0c91e6bf6", 1, UUIDVariantRFC9562},
+		{"919108f7-52d1-4320-9bac-f847db4148a8", 4, UUIDVariantRFC9562},
+		{"017f22e2-79b0-7cc3-98c4-dc0c0c07398f", 7, UUIDVariantRFC9562},
+		{"00000000-0000-0000-0000-000000000000", 0, UUIDVariantNCS},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", 15, UUIDVariantFuture},
+		{"00000000-0000-0000-c000-000000000000", 0, UUIDVariantMicrosoft},
+		{"00000000-0000-0000-7fff-000000000000", 0, UUIDVariantNCS},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			u, err := U128FromUUID(tc.in)
+			tt.MustOK(err)
+			tt.MustEqual(tc.version, u.UUIDVersion())
+			tt.MustEqual(tc.variant, u.UUIDVariant())
+		})
+	}
+}
+
+type fixedRandSource uint64
+
+func (f fixedRandSource) Uint64() uint64 { return uint64(f) }
+
+func TestRandUUIDv4(t *testing.T) {
+	tt := assert.WrapTB(t)
+	tt.MustEqual("ffffffff-ffff-4fff-bfff-ffffffffffff", RandUUIDv4(fixedRandSource(maxUint64)).UUIDString())
+	tt.MustEqual("00000000-0000-4000-8000-000000000000", RandUUIDv4(fixedRandSource(0)).UUIDString())
+
+	for i := 0; i < 1000; i++ {
+		u := RandUUIDv4(globalRNG)
+		tt.MustEqual(4, u.UUIDVersion())
+		tt.MustEqual(UUIDVariantRFC9562, u.UUIDVariant())
+	}
+}
+
+func TestRandUUIDv7(t *testing.T) {
+	tt := assert.WrapTB(t)
+
+	// Example from RFC 9562, Appendix A.6; Tuesday, February 22, 2022
+	// 2:22:22.00 PM GMT-05:00:
+	ts := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	u := RandUUIDv7(fixedRandSource(0), ts)
+	tt.MustEqual("017f22e2-79b0-7000-8000-000000000000", u.UUIDString())
+	tt.MustEqual(7, u.UUIDVersion())
+	tt.MustEqual(UUIDVariantRFC9562, u.UUIDVariant())
+
+	u = RandUUIDv7(fixedRandSource(maxUint64), ts)
+	tt.MustEqual("017f22e2-79b0-7fff-bfff-ffffffffffff", u.UUIDString())
+
+	// Sub-millisecond precision is discarded:
+	u = RandUUIDv7(fixedRandSource(0), ts.Add(999*time.Microsecond))
+	tt.MustEqual("017f22e2-79b0-7000-8000-000000000000", u.UUIDString())
+
+	// UUIDs from later milliseconds sort after earlier ones:
+	prev := RandUUIDv7(globalRNG, ts)
+	for i := 1; i < 1000; i++ {
+		next := RandUUIDv7(globalRNG, ts.Add(time.Duration(i)*time.Millisecond))
+		tt.MustAssert(next.GreaterThan(prev))
+		prev = next
+	}
+}
@@ -0,0 +1,158 @@
+package num
+
+import (
+	"encoding/hex"
+	"io"
+)
+
+// MaxVarintLen128 is the maximum length of a varint-encoded U128 or I128.
+const MaxVarintLen128 = 19
+
+// PutUvarint encodes u into buf using the same unsigned LEB128 format as
+// encoding/binary.PutUvarint, and returns the number of bytes written. Values
+// that fit in a uint64 produce exactly the same bytes as encoding/binary.
+//
+// buf must be large enough to hold the result; MaxVarintLen128 bytes is
+// always enough. PutUvarint panics if it is not.
+func (u U128) PutUvarint(buf []byte) int {
+	hi, lo := u.hi, u.lo
+	i := 0
+	for hi != 0 || lo >= 0x80 {
+		buf[i] = byte(lo) | 0x80
+		lo = lo>>7 | hi<<57
+		hi >>= 7
+		i++
+	}
+	buf[i] = byte(lo)
+	return i + 1
+}
+
+// AppendUvarint appends the varint encoding of u, as produced by PutUvarint,
+// to dst and returns the extended buffer.
+func (u U128) AppendUvarint(dst []byte) []byte {
+	var buf [MaxVarintLen128]byte
+	n := u.PutUvarint(buf[:])
+	return append(dst, buf[:n]...)
+}
+
+// U128FromUvarint decodes a U128 from buf, as encoded by PutUvarint, and
+// returns the value and the number of bytes read (> 0). As with
+// encoding/binary.Uvarint, if an error occurred n is:
+//
+//	n == 0: buf too small
+//	n  < 0: value larger than 128 bits (overflow); -n is the number of
+//	        bytes read
+func U128FromUvarint(buf []byte) (out U128, n int) {
+	var hi, lo uint64
+	var s uint
+	for i, b := range buf {
+		if i == MaxVarintLen128-1 && b > 3 {
+			// Only the lowest 2 bits of the last byte are available, and it
+			// must not have a continuation bit:
+			return zeroU128, -(i + 1)
+		}
+
+		v := uint64(b & 0x7f)
+		if s < 64 {
+			lo |= v << s
+			if s > 64-7 {
+				hi |= v >> (64 - s)
+			}
+		} else {
+			hi |= v << (s - 64)
+		}
+
+		if b < 0x80 {
+			return U128{hi: hi, lo: lo}, i + 1
+		}
+		s += 7
+	}
+	return zeroU128, 0
+}
+
+// ReadU128Uvarint reads a varint-encoded U128 from r. As with
+// encoding/binary.ReadUvarint, err is io.EOF only if no bytes were read; if
+// EOF happens after reading some but not all the bytes, err is
+// io.ErrUnexpectedEOF. If the value overflows a U128, err is a *NumError
+// wrapping ErrRange.
+func ReadU128Uvarint(r io.ByteReader) (out U128, err error) {
+	return readUvarint("ReadU128Uvarint", r)
+}
+
+func readUvarint(fn string, r io.ByteReader) (out U128, err error) {
+	var buf [MaxVarintLen128]byte
+	for i := 0; i < MaxVarintLen128; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			if i > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return zeroU128, err
+		}
+		buf[i] = b
+		if b < 0x80 {
+			out, n := U128FromUvarint(buf[:i+1])
+			if n <= 0 {
+				return zeroU128, rangeError(fn, hex.EncodeToString(buf[:i+1]))
+			}
+			return out, nil
+		}
+	}
+	return zeroU128, rangeError(fn, hex.EncodeToString(buf[:]))
+}
+
+// PutVarint encodes i into buf using the same zig-zag format as
+// encoding/binary.PutVarint, and returns the number of bytes written. Values
+// that fit in an int64 produce exactly the same bytes as encoding/binary.
+//
+// buf must be large enough to hold the result; MaxVarintLen128 bytes is
+// always enough. PutVarint panics if it is not.
+func (i I128) PutVarint(buf []byte) int {
+	return i.zigZag().PutUvarint(buf)
+}
+
+// AppendVarint appends the varint encoding of i, as produced by PutVarint, to
+// dst and returns the extended buffer.
+func (i I128) AppendVarint(dst []byte) []byte {
+	return i.zigZag().AppendUvarint(dst)
+}
+
+// I128FromVarint decodes an I128 from buf, as encoded by PutVarint, and
+// returns the value and the number of bytes read (> 0). If an error occurred,
+// n has the same meaning as it does for U128FromUvarint.
+func I128FromVarint(buf []byte) (out I128, n int) {
+	u, n := U128FromUvarint(buf)
+	if n <= 0 {
+		return zeroI128, n
+	}
+	return i128FromZigZag(u), n
+}
+
+// ReadI128Varint reads a varint-encoded I128 from r. Errors are reported in
+// the same way as ReadU128Uvarint.
+func ReadI128Varint(r io.ByteReader) (out I128, err error) {
+	u, err := readUvarint("ReadI128Varint", r)
+	if err != nil {
+		return zeroI128, err
+	}
+	return i128FromZigZag(u), nil
+}
+
+// zigZag maps signed integers to unsigned integers so that numbers with a
+// small absolute value have a small varint encoding: 0, -1, 1, -2, 2, ...
+// become 0, 1, 2, 3, 4, ...
+func (i I128) zigZag() U128 {
+	hi, lo := i.hi<<1|i.lo>>63, i.lo<<1
+	if i.hi&signBit != 0 {
+		hi, lo = ^hi, ^lo
+	}
+	return U128{hi: hi, lo: lo}
+}
+
+func i128FromZigZag(u U128) I128 {
+	hi, lo := u.hi>>1, u.lo>>1|u.hi<<63
+	if u.lo&1 != 0 {
+		hi, lo = ^hi, ^lo
+	}
+	return I128{hi: hi, lo: lo}
+}
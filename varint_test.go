@@ -0,0 +1,164 @@
+package num
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/shabbyrobe/go-num/internal/assert"
+)
+
+func TestU128Uvarint(t *testing.T) {
+	for idx, tc := range []struct {
+		in  U128
+		out string
+	}{
+		{zeroU128, "00"},
+		{u64(1), "01"},
+		{u64(0x7f), "7f"},
+		{u64(0x80), "8001"},
+		{u64(300), "ac02"},
+		{u64(maxUint64), "ffffffffffffffffff01"},
+		{U128FromRaw(1, 0), "80808080808080808002"},
+		{MaxU128, "ffffffffffffffffffffffffffffffffffff03"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+
+			var buf [MaxVarintLen128]byte
+			n := tc.in.PutUvarint(buf[:])
+			tt.MustEqual(tc.out, hex.EncodeToString(buf[:n]))
+			tt.MustEqual(tc.out, hex.EncodeToString(tc.in.AppendUvarint(nil)))
+
+			v, rn := U128FromUvarint(buf[:n])
+			tt.MustEqual(n, rn)
+			tt.MustEqual(tc.in, v)
+
+			v, err := ReadU128Uvarint(bytes.NewReader(buf[:n]))
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestI128Varint(t *testing.T) {
+	for idx, tc := range []struct {
+		in  I128
+		out string
+	}{
+		{zeroI128, "00"},
+		{i64(-1), "01"},
+		{i64(1), "02"},
+		{i64(-2), "03"},
+		{i64(-64), "7f"},
+		{i64(64), "8001"},
+		{i64(minInt64), "ffffffffffffffffff01"},
+		{i64(maxInt64), "feffffffffffffffff01"},
+		{MaxI128, "feffffffffffffffffffffffffffffffffff03"},
+		{MinI128, "ffffffffffffffffffffffffffffffffffff03"},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+
+			var buf [MaxVarintLen128]byte
+			n := tc.in.PutVarint(buf[:])
+			tt.MustEqual(tc.out, hex.EncodeToString(buf[:n]))
+			tt.MustEqual(tc.out, hex.EncodeToString(tc.in.AppendVarint([]byte{})))
+
+			v, rn := I128FromVarint(buf[:n])
+			tt.MustEqual(n, rn)
+			tt.MustEqual(tc.in, v)
+
+			v, err := ReadI128Varint(bytes.NewReader(buf[:n]))
+			tt.MustOK(err)
+			tt.MustEqual(tc.in, v)
+		})
+	}
+}
+
+func TestVarintCompatibleWithEncodingBinary(t *testing.T) {
+	tt := assert.WrapTB(t)
+	var expected [binary.MaxVarintLen64]byte
+	var result [MaxVarintLen128]byte
+
+	for i := 0; i < 10000; i++ {
+		u := globalRNG.Uint64() >> uint(globalRNG.Intn(64))
+		en := binary.PutUvarint(expected[:], u)
+		rn := u64(u).PutUvarint(result[:])
+		tt.MustEqual(expected[:en], result[:rn])
+
+		s := int64(u)
+		if i%2 == 0 {
+			s = -s
+		}
+		en = binary.PutVarint(expected[:], s)
+		rn = i64(s).PutVarint(result[:])
+		tt.MustEqual(expected[:en], result[:rn])
+	}
+}
+
+func TestVarintRoundTrip(t *testing.T) {
+	tt := assert.WrapTB(t)
+	scratch := make([]byte, 16)
+
+	var buf []byte
+	var us []U128
+	var is []I128
+	for i := 0; i < 1000; i++ {
+		u := randU128(scratch)
+		v := randI128(scratch)
+		if i%2 == 0 {
+			v = v.Neg()
+		}
+		us, is = append(us, u), append(is, v)
+		buf = u.AppendUvarint(buf)
+		buf = v.AppendVarint(buf)
+	}
+
+	rdr := bytes.NewReader(buf)
+	for i := range us {
+		u, err := ReadU128Uvarint(rdr)
+		tt.MustOK(err)
+		tt.MustEqual(us[i], u)
+
+		v, err := ReadI128Varint(rdr)
+		tt.MustOK(err)
+		tt.MustEqual(is[i], v)
+	}
+	_, err := ReadU128Uvarint(rdr)
+	tt.MustEqual(io.EOF, err)
+}
+
+func TestUvarintErrors(t *testing.T) {
+	for idx, tc := range []struct {
+		in      string
+		n       int
+		readErr error
+	}{
+		{"", 0, io.EOF},
+		{"80", 0, io.ErrUnexpectedEOF},
+		{"ffffffffffffffffffffffffffffffffffff", 0, io.ErrUnexpectedEOF},
+		{"ffffffffffffffffffffffffffffffffffff04", -19, ErrRange},
+		{"ffffffffffffffffffffffffffffffffffff80", -19, ErrRange},
+		{"80808080808080808080808080808080808080", -19, ErrRange},
+	} {
+		t.Run(fmt.Sprintf("%d/%s", idx, tc.in), func(t *testing.T) {
+			tt := assert.WrapTB(t)
+			bts, _ := hex.DecodeString(tc.in)
+
+			_, n := U128FromUvarint(bts)
+			tt.MustEqual(tc.n, n)
+			_, n = I128FromVarint(bts)
+			tt.MustEqual(tc.n, n)
+
+			_, err := ReadU128Uvarint(bytes.NewReader(bts))
+			tt.MustAssert(errors.Is(err, tc.readErr), "%v", err)
+			_, err = ReadI128Varint(bytes.NewReader(bts))
+			tt.MustAssert(errors.Is(err, tc.readErr), "%v", err)
+		})
+	}
+}